@@ -10,3 +10,5 @@ require (
 	github.com/xlab/linmath v0.0.0-20170502193301-512668b827be // indirect
 	github.com/zserge/metric v0.1.0
 )
+
+replace github.com/erinpentecost/gloop => ../../
@@ -49,6 +49,6 @@ func (m *MetricsServer) Serve(done <-chan interface{}) {
 
 // Publish takes in some sample.
 func (m *MetricsServer) Publish(sample gloop.LatencySample) {
-	m.renderLatency.Add(float64(sample.RenderLatency) / 1000000.0)
-	m.simulateLatency.Add(float64(sample.SimulateLatency) / 1000000.0)
+	m.renderLatency.Add(sample.RenderLatencyMs())
+	m.simulateLatency.Add(sample.SimulateLatencyMs())
 }
@@ -0,0 +1,39 @@
+package gloop
+
+import (
+	"time"
+)
+
+// SetAccumulator sets how much simulation time has built up towards
+// the next step, for setting up precise scenarios in tests, or after
+// rolling the world back. It takes effect at the start of the next
+// simulate frame that isn't paused, before that frame's time is added,
+// so a d just under SimulationLatency makes the next frame run one step.
+// A d of SimulationLatency or more makes that frame catch up, within
+// the usual limits on catch-up.
+// It can only be called before the loop is started, or while the
+// simulation is paused. d can't be negative.
+func (l *Loop) SetAccumulator(d time.Duration) error {
+	if d < 0 {
+		return wrapLoopError(nil, TokenLoop, "Accumulator can't be lt 0")
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.curState != stateInit && !l.paused() {
+		return wrapLoopError(nil, TokenLoop, "Accumulator can only be set before starting or while paused")
+	}
+	l.setAcc = &d
+	return nil
+}
+
+// takeAccumulator applies any value from SetAccumulator.
+func (r *runner) takeAccumulator() {
+	l := r.l
+	l.mu.Lock()
+	set := l.setAcc
+	l.setAcc = nil
+	l.mu.Unlock()
+	if set != nil {
+		r.simAccumulator = *set
+	}
+}
@@ -0,0 +1,60 @@
+package gloop_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/erinpentecost/gloop"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetAccumulator(t *testing.T) {
+	const simLatency = 100 * time.Millisecond
+	ticks := make(chan time.Time)
+	simulations := 0
+	var perFrame []int
+	simulate := func(step time.Duration) error {
+		simulations++
+		return nil
+	}
+	render := func(step time.Duration) error {
+		perFrame = append(perFrame, simulations)
+		simulations = 0
+		return nil
+	}
+	// Frame times are made up, and the first frame is 2ms long.
+	loop, err := gloop.NewLoop(render, simulate, gloop.Hz60Delay, simLatency,
+		gloop.WithExternalTick(ticks), gloop.WithInitialStep(2*time.Millisecond))
+	assert.Nil(t, err)
+
+	assert.NotNil(t, loop.SetAccumulator(-1))
+	// Just under one tick.
+	assert.Nil(t, loop.SetAccumulator(simLatency-time.Millisecond))
+
+	start := time.Now()
+	assert.Nil(t, loop.Start())
+	assert.NotNil(t, loop.SetAccumulator(0))
+	ticks <- start
+	ticks <- start.Add(50 * time.Millisecond)
+	close(ticks)
+	<-loop.Done()
+	assert.Nil(t, loop.Err())
+
+	// 99ms built up plus 2ms is one step, with 1ms left over.
+	// Another 50ms isn't enough for a second.
+	assert.Equal(t, []int{1, 0}, perFrame)
+}
+
+func TestSetAccumulatorWhilePaused(t *testing.T) {
+	loop, err := gloop.NewLoop(nil, nil, gloop.Hz60Delay, gloop.Hz60Delay)
+	assert.Nil(t, err)
+	assert.Nil(t, loop.Start())
+	defer func() {
+		loop.Stop(nil)
+		<-loop.Done()
+	}()
+
+	assert.NotNil(t, loop.SetAccumulator(0))
+	loop.PauseSimulation()
+	assert.Nil(t, loop.SetAccumulator(0))
+}
@@ -0,0 +1,55 @@
+package gloop
+
+import (
+	"runtime"
+)
+
+// WithAllocSampling measures how much Render and Simulate allocate,
+// once every n calls of each, and reports the most recent sample in
+// LoopStats. It is for tracking down garbage, and is expensive:
+// each sample reads runtime.MemStats twice, which stops the world.
+// The counts are process wide, so allocations made by other goroutines
+// while a sampled callback runs are counted too.
+func WithAllocSampling(n int) Option {
+	return func(l *Loop) error {
+		if n <= 0 {
+			return wrapLoopError(nil, TokenLoop, "AllocSampling can't be lte 0")
+		}
+		l.allocEvery = n
+		return nil
+	}
+}
+
+// sampleAllocs wraps fn to measure its allocations, if this call
+// of the callback for region is due to be sampled.
+func (r *runner) sampleAllocs(region string, fn func() error) func() error {
+	l := r.l
+	if l.allocEvery == 0 {
+		return fn
+	}
+	calls := &r.renderCalls
+	if region == simulateRegion {
+		calls = &r.simCalls
+	}
+	*calls++
+	if *calls%l.allocEvery != 0 {
+		return fn
+	}
+
+	return func() error {
+		var before, after runtime.MemStats
+		runtime.ReadMemStats(&before)
+		err := fn()
+		runtime.ReadMemStats(&after)
+		allocs := after.Mallocs - before.Mallocs
+		bytes := after.TotalAlloc - before.TotalAlloc
+		l.updateStats(func(stats *LoopStats) {
+			if region == simulateRegion {
+				stats.SimulateAllocs, stats.SimulateAllocBytes = allocs, bytes
+			} else {
+				stats.RenderAllocs, stats.RenderAllocBytes = allocs, bytes
+			}
+		})
+		return err
+	}
+}
@@ -0,0 +1,46 @@
+package gloop_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/erinpentecost/gloop"
+	"github.com/stretchr/testify/assert"
+)
+
+// garbage keeps allocations from being optimized away.
+var garbage [][]byte
+
+func TestAllocSampling(t *testing.T) {
+	simulate := func(step time.Duration) error {
+		garbage = append(garbage[:0], make([]byte, 4096))
+		return nil
+	}
+	render := func(step time.Duration) error { return nil }
+	loop, err := gloop.NewLoop(render, simulate, gloop.Hz60Delay, gloop.Hz60Delay, gloop.WithAllocSampling(2))
+	assert.Nil(t, err)
+
+	assert.Zero(t, loop.Stats().SimulateAllocBytes)
+	assert.Nil(t, loop.StepN(1))
+	// The first call isn't sampled.
+	assert.Zero(t, loop.Stats().SimulateAllocBytes)
+	assert.Nil(t, loop.StepN(1))
+
+	stats := loop.Stats()
+	assert.True(t, stats.SimulateAllocs >= 1, "allocs: %d", stats.SimulateAllocs)
+	assert.True(t, stats.SimulateAllocBytes >= 4096, "bytes: %d", stats.SimulateAllocBytes)
+
+	_, err = gloop.NewLoop(nil, nil, gloop.Hz60Delay, gloop.Hz60Delay, gloop.WithAllocSampling(0))
+	assert.NotNil(t, err)
+}
+
+func TestAllocSamplingOff(t *testing.T) {
+	simulate := func(step time.Duration) error {
+		garbage = append(garbage[:0], make([]byte, 4096))
+		return nil
+	}
+	loop, err := gloop.NewLoop(nil, simulate, gloop.Hz60Delay, gloop.Hz60Delay)
+	assert.Nil(t, err)
+	assert.Nil(t, loop.StepN(10))
+	assert.Zero(t, loop.Stats().SimulateAllocBytes)
+}
@@ -0,0 +1,54 @@
+package gloop
+
+import (
+	"context"
+	"time"
+)
+
+// autoTuneSteps is how many Simulate steps AutoTune measures.
+const autoTuneSteps = 300
+
+// autoTuneHeadroom is how much longer than the p95 service time
+// the tuned SimulationLatency is.
+const autoTuneHeadroom = 1.5
+
+// autoTuneFloor is the shortest SimulationLatency AutoTune picks.
+// Timers can't fire much faster than this anyway.
+const autoTuneFloor = time.Millisecond
+
+// AutoTune measures how long Simulate takes on this machine and picks
+// a SimulationLatency it can keep up with: the p95 service time, plus
+// 50% headroom, but never less than 1ms. It sets SimulationLatency
+// and returns it, replacing any rate set by WithSimulationRate.
+// AutoTune calls Simulate a few hundred times in step mode, so call it
+// before the loop is started, and before the world is set up if
+// those steps would matter. Like any step mode steps, they count
+// towards Stats().SimTicks and LifetimeQuantile.
+// It stops early if ctx is done.
+func (l *Loop) AutoTune(ctx context.Context) (time.Duration, error) {
+	r, err := l.stepRunner()
+	if err != nil {
+		return 0, err
+	}
+
+	times := make([]time.Duration, 0, autoTuneSteps)
+	for i := 0; i < autoTuneSteps; i++ {
+		if err := ctx.Err(); err != nil {
+			return 0, wrapLoopError(err, TokenLoop, "AutoTune was cancelled")
+		}
+		start := time.Now()
+		if err := r.stepSimulate(i); err != nil {
+			return 0, err
+		}
+		times = append(times, time.Since(start))
+	}
+
+	latency := time.Duration(float64(percentiles(times).P95) * autoTuneHeadroom)
+	if latency < autoTuneFloor {
+		latency = autoTuneFloor
+	}
+	// The tuned latency replaces any exact rate.
+	l.simRate = nil
+	l.SimulationLatency = latency
+	return latency, nil
+}
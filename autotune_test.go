@@ -0,0 +1,51 @@
+package gloop_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/erinpentecost/gloop"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAutoTune(t *testing.T) {
+	const cost = 2 * time.Millisecond
+	simulate := func(step time.Duration) error {
+		end := time.Now().Add(cost)
+		for time.Now().Before(end) {
+		}
+		return nil
+	}
+	loop, err := gloop.NewLoop(nil, simulate, gloop.Hz60Delay, gloop.Hz60Delay)
+	assert.Nil(t, err)
+
+	latency, err := loop.AutoTune(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, latency, loop.SimulationLatency)
+	// At least 50% headroom over the known cost, without being absurd.
+	assert.True(t, latency >= cost*3/2, "latency: %s", latency)
+	assert.True(t, latency < 20*cost, "latency: %s", latency)
+}
+
+func TestAutoTuneFloor(t *testing.T) {
+	simulate := func(step time.Duration) error { return nil }
+	loop, err := gloop.NewLoop(nil, simulate, gloop.Hz60Delay, gloop.Hz60Delay)
+	assert.Nil(t, err)
+
+	latency, err := loop.AutoTune(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, time.Millisecond, latency)
+	assert.Equal(t, uint64(300), loop.Stats().SimTicks)
+}
+
+func TestAutoTuneCancelled(t *testing.T) {
+	loop, err := gloop.NewLoop(nil, nil, gloop.Hz60Delay, gloop.Hz60Delay)
+	assert.Nil(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = loop.AutoTune(ctx)
+	assert.NotNil(t, err)
+	assert.Equal(t, gloop.Hz60Delay, loop.SimulationLatency)
+}
@@ -0,0 +1,55 @@
+package gloop
+
+import (
+	"time"
+)
+
+// ResetBaseline tells the loop that a long stall, like a level load,
+// was expected. The time since the last frame of each kind is thrown
+// away rather than caught up on, any built up simulation time is
+// dropped, and the latencies in the next heartbeat are measured from
+// the reset, so the stall doesn't show up as lag.
+// It takes effect at the start of the next simulate and render frame,
+// so it can be called from inside Simulate or Render once the stall is
+// over. Dropped time isn't counted in TotalDroppedSimTicks.
+func (l *Loop) ResetBaseline() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.baseline++
+}
+
+// baselineCount is how many times ResetBaseline has been called.
+func (l *Loop) baselineCount() uint64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.baseline
+}
+
+// rebaseSimulation applies any ResetBaseline to simulation,
+// treating curTime as caught up.
+func (r *runner) rebaseSimulation(curTime time.Time) {
+	count := r.l.baselineCount()
+	if count == r.simBase {
+		return
+	}
+	r.simBase = count
+	r.previousSim = curTime
+	r.simAccumulator = 0
+	r.mu.Lock()
+	r.simLatency = newLatencyTracker()
+	r.mu.Unlock()
+}
+
+// rebaseRender applies any ResetBaseline to rendering,
+// treating curTime as caught up.
+func (r *runner) rebaseRender(curTime time.Time) {
+	count := r.l.baselineCount()
+	if count == r.rendBase {
+		return
+	}
+	r.rendBase = count
+	r.previousRend = curTime
+	r.mu.Lock()
+	r.rendLatency = newLatencyTracker()
+	r.mu.Unlock()
+}
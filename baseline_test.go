@@ -0,0 +1,73 @@
+package gloop_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/erinpentecost/gloop"
+	"github.com/stretchr/testify/assert"
+)
+
+// stallOnce returns a Simulate that stalls for stall on its third step,
+// calling after once the stall is over, and records when each step ran.
+func stallOnce(stall time.Duration, after func()) (gloop.LoopFn, *[]time.Time, *time.Time) {
+	var steps []time.Time
+	var stalled time.Time
+	return func(step time.Duration) error {
+		steps = append(steps, time.Now())
+		if len(steps) == 3 {
+			time.Sleep(stall)
+			stalled = time.Now()
+			after()
+		}
+		return nil
+	}, &steps, &stalled
+}
+
+// burstAfter counts steps in the window just after the stall.
+func burstAfter(steps []time.Time, stalled time.Time, window time.Duration) int {
+	count := 0
+	for _, at := range steps {
+		if at.After(stalled) && at.Sub(stalled) < window {
+			count++
+		}
+	}
+	return count
+}
+
+func TestResetBaseline(t *testing.T) {
+	const latency = 10 * time.Millisecond
+	loop, err := gloop.NewLoop(nil, nil, time.Second, latency)
+	assert.Nil(t, err)
+	simulate, steps, stalled := stallOnce(20*latency, loop.ResetBaseline)
+	loop.Simulate = simulate
+
+	assert.Nil(t, loop.Start())
+	sample := <-loop.Heartbeat()
+	loop.Stop(nil)
+	<-loop.Done()
+	assert.Nil(t, loop.Err())
+
+	// No catch-up burst after the stall.
+	burst := burstAfter(*steps, *stalled, 3*latency)
+	assert.True(t, burst <= 4, "burst of %d", burst)
+	// The stall didn't show up as lag.
+	assert.True(t, sample.SimulateLatency < 5*latency, "latency %s", sample.SimulateLatency)
+}
+
+func TestStallWithoutResetBaseline(t *testing.T) {
+	const latency = 10 * time.Millisecond
+	loop, err := gloop.NewLoop(nil, nil, time.Second, latency)
+	assert.Nil(t, err)
+	simulate, steps, stalled := stallOnce(20*latency, func() {})
+	loop.Simulate = simulate
+
+	assert.Nil(t, loop.Start())
+	time.Sleep(50 * latency)
+	loop.Stop(nil)
+	<-loop.Done()
+
+	// Without it, the loop catches up on the stall all at once.
+	burst := burstAfter(*steps, *stalled, 3*latency)
+	assert.True(t, burst >= 15, "burst of %d", burst)
+}
@@ -0,0 +1,72 @@
+package gloop
+
+import (
+	"time"
+)
+
+// BatchFn is called once per simulate frame by WithBatchSimulate.
+// tick is the number of the first Simulate step this frame, counting
+// from 1, step is the fixed step, and ticks is how many steps the
+// frame will run.
+type BatchFn func(tick uint64, step time.Duration, ticks int) error
+
+// WithBatchSimulate calls fn once at the start of each simulate frame
+// that will run at least one step, before any of them, with how many
+// steps it will run. An entity component system can use it to update
+// every entity for the whole frame at once, rather than step by step.
+// Simulate is still called for each step, if set.
+// An error from fn stops the loop, as one from Simulate would, and no
+// steps run that frame.
+func WithBatchSimulate(fn BatchFn) Option {
+	return func(l *Loop) error {
+		l.batchSimulate = fn
+		return nil
+	}
+}
+
+// simulateBatch calls the batch hook, if there is one, with how many
+// steps this frame will run.
+func (r *runner) simulateBatch(curTime time.Time, maxSteps int) {
+	l := r.l
+	if l.batchSimulate == nil {
+		return
+	}
+	ticks := r.plannedSteps(maxSteps)
+	if ticks == 0 {
+		return
+	}
+	tick := l.Stats().SimTicks + 1
+	step := r.simStep()
+	er := r.invoke(simulateLabels, simulateRegion, step, func() error {
+		return l.batchSimulate(tick, step, ticks)
+	})
+	if er != nil {
+		wrapped := wrapLoopError(er, TokenSimulate, "Error returned by batch Simulate(%s, %d)", step.String(), ticks)
+		wrapped.Misc["curTime"] = curTime
+		l.reportError(wrapped)
+		l.Stop(wrapped)
+	}
+}
+
+// plannedSteps is how many steps the simulate frame will run, given
+// what has built up, without running any of them.
+// It follows the same rules as simulateFrame.
+func (r *runner) plannedSteps(maxSteps int) int {
+	l := r.l
+	saved := r.rateRemainder
+	defer func() { r.rateRemainder = saved }()
+
+	acc, steps := r.simAccumulator, 0
+	for step := r.simStep(); acc >= step; step = r.simStep() {
+		acc -= step
+		r.advanceSimStep()
+		steps++
+		if l.noCatchUp {
+			break
+		}
+		if maxSteps > 0 && steps >= maxSteps {
+			acc %= l.SimulationLatency
+		}
+	}
+	return steps
+}
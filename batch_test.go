@@ -0,0 +1,94 @@
+package gloop_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/erinpentecost/gloop"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBatchSimulate(t *testing.T) {
+	const latency = 10 * time.Millisecond
+	type batch struct {
+		tick  uint64
+		step  time.Duration
+		ticks int
+	}
+	var batches []batch
+	simulations := 0
+	hook := func(tick uint64, step time.Duration, ticks int) error {
+		batches = append(batches, batch{tick, step, ticks})
+		return nil
+	}
+	simulate := func(step time.Duration) error {
+		simulations++
+		return nil
+	}
+	// The first frame catches up on the initial step in one burst.
+	loop, err := gloop.NewLoop(nil, simulate, time.Second, latency,
+		gloop.WithBatchSimulate(hook), gloop.WithInitialStep(5*latency))
+	assert.Nil(t, err)
+	assert.Nil(t, loop.Start())
+	time.Sleep(5 * latency)
+	loop.Stop(nil)
+	<-loop.Done()
+	assert.Nil(t, loop.Err())
+
+	assert.True(t, len(batches) > 1)
+	assert.Equal(t, batch{1, latency, 5}, batches[0])
+	// Every step was announced by the batch before it.
+	total := 0
+	for _, b := range batches {
+		assert.Equal(t, uint64(total+1), b.tick)
+		total += b.ticks
+	}
+	// Stop may land part way through the last batch.
+	last := batches[len(batches)-1].ticks
+	assert.True(t, simulations <= total && simulations >= total-last)
+}
+
+func TestBatchSimulateCapped(t *testing.T) {
+	const latency = 10 * time.Millisecond
+	var first int
+	hook := func(tick uint64, step time.Duration, ticks int) error {
+		if tick == 1 {
+			first = ticks
+		}
+		return nil
+	}
+	loop, err := gloop.NewLoop(nil, nil, time.Second, latency,
+		gloop.WithBatchSimulate(hook), gloop.WithInitialStep(10*latency),
+		gloop.WithMaxSimStepsPerFrame(3))
+	assert.Nil(t, err)
+	assert.Nil(t, loop.Start())
+	time.Sleep(2 * latency)
+	loop.Stop(nil)
+	<-loop.Done()
+
+	assert.Equal(t, 3, first)
+}
+
+func TestBatchSimulateError(t *testing.T) {
+	failure := errors.New("batch failed")
+	simulations := 0
+	hook := func(tick uint64, step time.Duration, ticks int) error {
+		return failure
+	}
+	simulate := func(step time.Duration) error {
+		simulations++
+		return nil
+	}
+	loop, err := gloop.NewLoop(nil, simulate, gloop.Hz60Delay, gloop.Hz60Delay,
+		gloop.WithBatchSimulate(hook))
+	assert.Nil(t, err)
+	assert.Nil(t, loop.Start())
+	<-loop.Done()
+
+	var loopErr gloop.LoopError
+	assert.True(t, errors.As(loop.Err(), &loopErr))
+	assert.Equal(t, gloop.TokenSimulate, loopErr.ErrorSource)
+	assert.Equal(t, failure, loopErr.Inner)
+	assert.Equal(t, 0, simulations)
+}
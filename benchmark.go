@@ -0,0 +1,83 @@
+package gloop
+
+import (
+	"sort"
+	"time"
+)
+
+// Percentiles summarizes how long a callback took.
+type Percentiles struct {
+	P50 time.Duration
+	P95 time.Duration
+	P99 time.Duration
+	Max time.Duration
+}
+
+// BenchmarkReport is what Benchmark measured.
+type BenchmarkReport struct {
+	// Frames is how many frames ran.
+	Frames int
+	// Simulate is how long each Simulate step took.
+	Simulate Percentiles
+	// Render is how long each Render, including layers, took.
+	Render Percentiles
+	// Throughput is how many frames ran per second of wall time.
+	Throughput float64
+}
+
+// Benchmark runs frames iterations in step mode, as Warmup does, and
+// reports how long the real callbacks took. Use it as a performance
+// gate in CI. Like StepN, it only works before the loop is started,
+// and returns the first callback error.
+func (l *Loop) Benchmark(frames int) (BenchmarkReport, error) {
+	r, err := l.stepRunner()
+	if err != nil {
+		return BenchmarkReport{}, err
+	}
+
+	simTimes := make([]time.Duration, 0, frames)
+	renderTimes := make([]time.Duration, 0, frames)
+	start := time.Now()
+	for i := 0; i < frames && !l.stopping(); i++ {
+		simTime, renderTime, err := r.stepFrame(i)
+		if err != nil {
+			return BenchmarkReport{}, err
+		}
+		simTimes = append(simTimes, simTime)
+		renderTimes = append(renderTimes, renderTime)
+	}
+	elapsed := time.Since(start)
+
+	report := BenchmarkReport{
+		Frames:   len(simTimes),
+		Simulate: percentiles(simTimes),
+		Render:   percentiles(renderTimes),
+	}
+	if elapsed > 0 {
+		report.Throughput = float64(report.Frames) / elapsed.Seconds()
+	}
+	return report, nil
+}
+
+// percentiles sorts times and picks out the percentiles by nearest rank.
+func percentiles(times []time.Duration) Percentiles {
+	if len(times) == 0 {
+		return Percentiles{}
+	}
+	sort.Slice(times, func(i, j int) bool { return times[i] < times[j] })
+	rank := func(p float64) time.Duration {
+		i := int(p*float64(len(times))+0.5) - 1
+		if i < 0 {
+			i = 0
+		} else if i >= len(times) {
+			i = len(times) - 1
+		}
+		return times[i]
+	}
+	return Percentiles{
+		P50: rank(0.50),
+		P95: rank(0.95),
+		P99: rank(0.99),
+		Max: times[len(times)-1],
+	}
+}
@@ -0,0 +1,56 @@
+package gloop_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/erinpentecost/gloop"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBenchmark(t *testing.T) {
+	frame := 0
+	spin := func(d time.Duration) {
+		end := time.Now().Add(d)
+		for time.Now().Before(end) {
+		}
+	}
+	render := func(step time.Duration) error {
+		frame++
+		// Every tenth frame is slow.
+		if frame%10 == 0 {
+			spin(2 * time.Millisecond)
+		} else {
+			spin(100 * time.Microsecond)
+		}
+		return nil
+	}
+	simulate := func(step time.Duration) error {
+		spin(50 * time.Microsecond)
+		return nil
+	}
+	loop, err := gloop.NewLoop(render, simulate, gloop.Hz60Delay, gloop.Hz60Delay)
+	assert.Nil(t, err)
+
+	report, err := loop.Benchmark(100)
+	assert.Nil(t, err)
+	assert.Equal(t, 100, report.Frames)
+	assert.True(t, report.Throughput > 0)
+
+	for _, p := range []gloop.Percentiles{report.Simulate, report.Render} {
+		assert.True(t, p.P50 > 0)
+		assert.True(t, p.P50 <= p.P95)
+		assert.True(t, p.P95 <= p.P99)
+		assert.True(t, p.P99 <= p.Max)
+	}
+	assert.True(t, report.Simulate.P50 >= 50*time.Microsecond)
+	assert.True(t, report.Render.P50 < time.Millisecond)
+	assert.True(t, report.Render.P95 >= 2*time.Millisecond)
+
+	// Benchmarks only run in step mode.
+	assert.Nil(t, loop.Start())
+	_, err = loop.Benchmark(1)
+	assert.NotNil(t, err)
+	loop.Stop(nil)
+	<-loop.Done()
+}
@@ -0,0 +1,60 @@
+package gloop
+
+import (
+	"time"
+)
+
+// callBudget is the time budget of a callback that is running
+// on the owner goroutine.
+type callBudget struct {
+	active  bool
+	owner   uint64
+	start   time.Time
+	latency time.Duration
+}
+
+// BudgetRemaining is how much of the running callback's budget is left.
+// It's meant to be called from inside Simulate or Render, so they can
+// work through a queue a bit at a time, and put the rest off to a later
+// frame once time runs low.
+// Simulate's budget is SimulationLatency, and Render's is the time
+// between render frames, which is RenderLatency unless
+// SetRenderRateLimit has lowered the rate. The budget starts when the
+// callback is called, and goes negative once it has overrun.
+// Called from anywhere else, it returns 0.
+func (l *Loop) BudgetRemaining() time.Duration {
+	now := time.Now()
+	id := goid()
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	// With concurrent render, both may be running at once.
+	for _, b := range l.budgets {
+		if b.active && b.owner == id {
+			return b.latency - now.Sub(b.start)
+		}
+	}
+	return 0
+}
+
+// enterBudget starts the budget for the callback for region,
+// until the returned func is called.
+func (r *runner) enterBudget(region string, start time.Time) func() {
+	l := r.l
+	b := callBudget{active: true, owner: goid(), start: start, latency: r.rendEvery}
+	slot := &l.budgets[1]
+	if region == simulateRegion {
+		b.latency = l.SimulationLatency
+		slot = &l.budgets[0]
+	} else if b.latency == 0 {
+		// Step mode has no render ticker.
+		b.latency = l.RenderLatency
+	}
+	l.mu.Lock()
+	*slot = b
+	l.mu.Unlock()
+	return func() {
+		l.mu.Lock()
+		*slot = callBudget{}
+		l.mu.Unlock()
+	}
+}
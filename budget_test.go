@@ -0,0 +1,87 @@
+package gloop_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/erinpentecost/gloop"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBudgetRemaining(t *testing.T) {
+	const latency = 20 * time.Millisecond
+	loop, err := gloop.NewLoop(nil, nil, latency, latency)
+	assert.Nil(t, err)
+	assert.Equal(t, time.Duration(0), loop.BudgetRemaining())
+
+	var first, second, overrun, other time.Duration
+	loop.Simulate = func(step time.Duration) error {
+		first = loop.BudgetRemaining()
+		// Other goroutines aren't running the callback.
+		done := make(chan struct{})
+		go func() {
+			other = loop.BudgetRemaining()
+			close(done)
+		}()
+		<-done
+		time.Sleep(5 * time.Millisecond)
+		second = loop.BudgetRemaining()
+		time.Sleep(latency)
+		overrun = loop.BudgetRemaining()
+		loop.Stop(nil)
+		return nil
+	}
+	assert.Nil(t, loop.Start())
+	<-loop.Done()
+	assert.Nil(t, loop.Err())
+
+	assert.True(t, first <= latency)
+	assert.True(t, first > 0)
+	assert.True(t, second <= first-5*time.Millisecond)
+	assert.True(t, overrun < 0)
+	// Outside a callback, there's no budget.
+	assert.Equal(t, time.Duration(0), other)
+	assert.Equal(t, time.Duration(0), loop.BudgetRemaining())
+}
+
+func TestBudgetRemainingConcurrentRender(t *testing.T) {
+	loop, err := gloop.NewLoop(nil, nil, 50*time.Millisecond, time.Millisecond,
+		gloop.WithConcurrentRender())
+	assert.Nil(t, err)
+
+	budgets := make(chan time.Duration, 1)
+	loop.Render = func(step time.Duration) error {
+		// Simulate is running far more often with a much smaller
+		// budget, but this sees Render's own.
+		time.Sleep(5 * time.Millisecond)
+		select {
+		case budgets <- loop.BudgetRemaining():
+		default:
+		}
+		return nil
+	}
+	assert.Nil(t, loop.Start())
+	budget := <-budgets
+	loop.Stop(nil)
+	<-loop.Done()
+
+	assert.True(t, budget > 10*time.Millisecond)
+	assert.True(t, budget < 50*time.Millisecond)
+}
+
+func TestBudgetRemainingStepMode(t *testing.T) {
+	const latency = 20 * time.Millisecond
+	loop, err := gloop.NewLoop(nil, nil, latency, latency)
+	assert.Nil(t, err)
+
+	var budget time.Duration
+	loop.Render = func(step time.Duration) error {
+		budget = loop.BudgetRemaining()
+		return nil
+	}
+	assert.Nil(t, loop.Warmup(1))
+
+	// Without a render ticker, the budget is RenderLatency.
+	assert.True(t, budget > 0)
+	assert.True(t, budget <= latency)
+}
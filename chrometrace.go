@@ -0,0 +1,89 @@
+package gloop
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+)
+
+// traceEvent is a complete event in the Chrome Trace Event format.
+type traceEvent struct {
+	Name string `json:"name"`
+	Ph   string `json:"ph"`
+	// Ts and Dur are in microseconds.
+	Ts   float64        `json:"ts"`
+	Dur  float64        `json:"dur"`
+	Pid  int            `json:"pid"`
+	Tid  int            `json:"tid"`
+	Args traceEventArgs `json:"args"`
+}
+
+// traceEventArgs is the extra detail shown for a selected event.
+type traceEventArgs struct {
+	Step string `json:"step"`
+}
+
+// newTraceEvent is a callback of source that ran for service from start.
+// Each source gets its own track.
+func newTraceEvent(source TokenSource, start time.Time, service, step time.Duration) traceEvent {
+	return traceEvent{
+		Name: source.String(),
+		Ph:   "X",
+		Ts:   float64(start.UnixNano()) / float64(time.Microsecond),
+		Dur:  float64(service) / float64(time.Microsecond),
+		Pid:  1,
+		Tid:  int(source),
+		Args: traceEventArgs{Step: step.String()},
+	}
+}
+
+// traceBuffer is a ring buffer of the most recent trace events.
+type traceBuffer struct {
+	events []traceEvent
+	// next is where the next event goes once the buffer is full.
+	next int
+}
+
+// push records e, overwriting the oldest event once limit are held.
+func (b *traceBuffer) push(limit int, e traceEvent) {
+	if len(b.events) < limit {
+		b.events = append(b.events, e)
+		return
+	}
+	b.events[b.next] = e
+	b.next = (b.next + 1) % limit
+}
+
+// take returns the events, oldest first, and empties the buffer.
+func (b *traceBuffer) take() []traceEvent {
+	out := append(b.events[b.next:len(b.events):len(b.events)], b.events[:b.next]...)
+	b.events, b.next = nil, 0
+	return out
+}
+
+// WithTraceEvents records each Simulate and Render call, up to the
+// last limit of them, for WriteTrace.
+func WithTraceEvents(limit int) Option {
+	return func(l *Loop) error {
+		if limit <= 0 {
+			return wrapLoopError(nil, TokenLoop, "TraceEvents can't be lte 0")
+		}
+		l.traceLimit = limit
+		return nil
+	}
+}
+
+// WriteTrace writes the calls recorded since the last WriteTrace to w,
+// as a JSON array in the Chrome Trace Event format, and forgets them.
+// Load the output in chrome://tracing or Perfetto to see a timeline,
+// with simulate and render calls on separate tracks.
+// It needs WithTraceEvents; without it, it writes an empty array.
+func (l *Loop) WriteTrace(w io.Writer) error {
+	l.mu.Lock()
+	events := l.trace.take()
+	l.mu.Unlock()
+	if events == nil {
+		events = []traceEvent{}
+	}
+	return json.NewEncoder(w).Encode(events)
+}
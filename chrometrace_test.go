@@ -0,0 +1,81 @@
+package gloop_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/erinpentecost/gloop"
+	"github.com/stretchr/testify/assert"
+)
+
+type chromeEvent struct {
+	Name string  `json:"name"`
+	Ph   string  `json:"ph"`
+	Ts   float64 `json:"ts"`
+	Dur  float64 `json:"dur"`
+	Pid  int     `json:"pid"`
+	Tid  int     `json:"tid"`
+}
+
+func TestWriteTrace(t *testing.T) {
+	loop, err := gloop.NewLoop(nil, nil, 5*time.Millisecond, 5*time.Millisecond,
+		gloop.WithTraceEvents(1000))
+	assert.Nil(t, err)
+	frames := 0
+	loop.Render = func(step time.Duration) error {
+		if frames++; frames == 5 {
+			loop.Stop(nil)
+		}
+		return nil
+	}
+	assert.Nil(t, loop.Start())
+	<-loop.Done()
+
+	var buf bytes.Buffer
+	assert.Nil(t, loop.WriteTrace(&buf))
+	var events []chromeEvent
+	assert.Nil(t, json.Unmarshal(buf.Bytes(), &events))
+
+	names := map[string]int{}
+	for i, event := range events {
+		names[event.Name]++
+		assert.Equal(t, "X", event.Ph)
+		assert.True(t, event.Ts > 0)
+		assert.True(t, event.Dur >= 0)
+		if i > 0 {
+			assert.True(t, event.Ts >= events[i-1].Ts)
+		}
+	}
+	assert.True(t, names["render"] >= 4)
+	assert.True(t, names["simulate"] > 0)
+	assert.Len(t, names, 2)
+
+	// The events were flushed.
+	buf.Reset()
+	assert.Nil(t, loop.WriteTrace(&buf))
+	assert.Equal(t, "[]\n", buf.String())
+}
+
+func TestWriteTraceLimit(t *testing.T) {
+	loop, err := gloop.NewLoop(nil, nil, time.Millisecond, time.Millisecond,
+		gloop.WithTraceEvents(10))
+	assert.Nil(t, err)
+	assert.Nil(t, loop.Start())
+	time.Sleep(50 * time.Millisecond)
+	loop.Stop(nil)
+	<-loop.Done()
+
+	var buf bytes.Buffer
+	assert.Nil(t, loop.WriteTrace(&buf))
+	var events []chromeEvent
+	assert.Nil(t, json.Unmarshal(buf.Bytes(), &events))
+	assert.Len(t, events, 10)
+	for i := 1; i < len(events); i++ {
+		assert.True(t, events[i].Ts >= events[i-1].Ts)
+	}
+
+	_, err = gloop.NewLoop(nil, nil, time.Millisecond, time.Millisecond, gloop.WithTraceEvents(0))
+	assert.NotNil(t, err)
+}
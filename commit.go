@@ -0,0 +1,32 @@
+package gloop
+
+// Commit schedules fn to run at the next frame boundary: after the
+// current simulate frame, including any catch-up steps, has finished,
+// and before the next render.
+// Use it to swap a back buffer written by Simulate to the front buffer
+// read by Render, so Render never sees a half-written frame.
+// Commits run in the order they were scheduled.
+// This is safe to call from anywhere, including inside Simulate.
+func (l *Loop) Commit(fn func()) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.commits = append(l.commits, fn)
+}
+
+// runCommits runs and clears the scheduled commits.
+func (l *Loop) runCommits() {
+	l.mu.Lock()
+	commits := l.commits
+	l.commits = nil
+	l.mu.Unlock()
+
+	if len(commits) == 0 {
+		return
+	}
+	// Never swap buffers in the middle of a render.
+	l.frameMu.Lock()
+	defer l.frameMu.Unlock()
+	for _, fn := range commits {
+		fn()
+	}
+}
@@ -0,0 +1,60 @@
+package gloop_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/erinpentecost/gloop"
+	"github.com/stretchr/testify/assert"
+)
+
+type world struct {
+	a, b int
+}
+
+func TestCommit(t *testing.T) {
+	front := &world{}
+	back := &world{}
+	loop, err := gloop.NewLoop(nil, nil, gloop.Hz60Delay, gloop.Hz60Delay)
+	assert.Nil(t, err)
+	assert.NotNil(t, loop)
+	loop.Simulate = func(step time.Duration) error {
+		// Write the back buffer in two halves.
+		back.a++
+		back.b++
+		loop.Commit(func() {
+			next := *back
+			front = &next
+		})
+		return nil
+	}
+	renders := 0
+	loop.Render = func(step time.Duration) error {
+		renders++
+		assert.Equal(t, front.a, front.b)
+		if renders == 10 {
+			loop.Stop(nil)
+		}
+		return nil
+	}
+	err = loop.Start()
+	assert.Nil(t, err)
+	<-loop.Done()
+	assert.Nil(t, loop.Err())
+	assert.True(t, front.a > 0)
+}
+
+func TestCommitStepMode(t *testing.T) {
+	commits := 0
+	loop, err := gloop.NewLoop(nil, nil, gloop.Hz60Delay, gloop.Hz60Delay)
+	assert.Nil(t, err)
+	assert.NotNil(t, loop)
+	loop.Simulate = func(step time.Duration) error {
+		loop.Commit(func() { commits++ })
+		// Commits wait for the frame boundary.
+		assert.Equal(t, 0, commits)
+		return nil
+	}
+	assert.Nil(t, loop.StepN(3))
+	assert.Equal(t, 3, commits)
+}
@@ -0,0 +1,61 @@
+package gloop
+
+import (
+	"time"
+)
+
+// WithConcurrentRender runs Render on its own goroutine, so it can
+// overlap with Simulate on another core.
+// Render must only read state that Simulate is done with. Have Simulate
+// write to a back buffer, and swap it to the front with Commit: commits
+// never run while a render frame is in progress, and no render frame
+// starts while commits are running.
+// Errors from either callback still stop the loop, and Done() still
+// doesn't close until both goroutines are finished.
+// WithRenderPriority has no effect, and this can't be combined with
+// WithRenderDriven.
+func WithConcurrentRender() Option {
+	return func(l *Loop) error {
+		l.concurrentRender = true
+		return nil
+	}
+}
+
+// renderLoop is the body of the render goroutine.
+func (r *runner) renderLoop() {
+	l := r.l
+	defer r.rendering.Done()
+	defer l.lockThread()()
+	l.trackGoroutine()
+	for {
+		if l.stopping() {
+			return
+		}
+		select {
+		case <-l.done:
+			return
+		case tick := <-r.rendTick.C:
+			r.renderFrame(tick)
+		}
+	}
+}
+
+// rendTickC is the render ticker's chan, as seen by the loop goroutine.
+// It is nil, and so never ready, when render has its own goroutine,
+// and it is the external tick chan with WithExternalTick.
+func (r *runner) rendTickC() <-chan time.Time {
+	if r.l.concurrentRender {
+		return nil
+	}
+	if r.l.externalTick != nil {
+		return r.l.externalTick
+	}
+	return r.rendTick.C
+}
+
+// finish waits for the render goroutine, if there is one,
+// and then signals Done().
+func (r *runner) finish() {
+	r.rendering.Wait()
+	r.l.signalDone()
+}
@@ -0,0 +1,86 @@
+package gloop_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/erinpentecost/gloop"
+	"github.com/stretchr/testify/assert"
+)
+
+// sharedWorld is written by Simulate and read by Render.
+type sharedWorld struct {
+	tick  int
+	ticks [16]int
+}
+
+func TestConcurrentRender(t *testing.T) {
+	const simLatency = gloop.Hz60Delay / 2
+	loop, err := gloop.NewLoop(nil, nil, gloop.Hz60Delay, simLatency, gloop.WithConcurrentRender())
+	assert.Nil(t, err)
+	assert.NotNil(t, loop)
+
+	front, back := &sharedWorld{}, &sharedWorld{}
+	simulations, renders := 0, 0
+	latest := 0
+	swapPending := false
+	loop.Simulate = func(step time.Duration) error {
+		simulations++
+		latest++
+		back.tick = latest
+		for i := range back.ticks {
+			back.ticks[i] = latest
+		}
+		// Commits run on the simulate side, so swapPending needs no lock.
+		if !swapPending {
+			swapPending = true
+			loop.Commit(func() {
+				front, back = back, front
+				swapPending = false
+			})
+		}
+		return nil
+	}
+	loop.Render = func(step time.Duration) error {
+		renders++
+		// Every commit is whole.
+		for _, tick := range front.ticks {
+			assert.Equal(t, front.tick, tick)
+		}
+		// Take long enough that a serial loop would hold up Simulate.
+		time.Sleep(simLatency)
+		return nil
+	}
+
+	assert.Nil(t, loop.Start())
+	<-time.After(500 * time.Millisecond)
+	loop.Stop(nil)
+	<-loop.Done()
+	assert.Nil(t, loop.Err())
+
+	// Both run at about their own rates: 60 and 30 in half a second.
+	stats := loop.Stats()
+	assert.Equal(t, uint64(simulations), stats.SimTicks)
+	assert.Equal(t, uint64(renders), stats.RenderFrames)
+	assert.True(t, simulations >= 45, "simulations: %d", simulations)
+	assert.True(t, renders >= 20, "renders: %d", renders)
+}
+
+func TestConcurrentRenderError(t *testing.T) {
+	render := func(step time.Duration) error {
+		return assert.AnError
+	}
+	loop, err := gloop.NewLoop(render, nil, gloop.Hz60Delay, gloop.Hz60Delay, gloop.WithConcurrentRender())
+	assert.Nil(t, err)
+	assert.Nil(t, loop.Start())
+	<-loop.Done()
+
+	loopErr, ok := loop.Err().(gloop.LoopError)
+	assert.True(t, ok)
+	assert.Equal(t, gloop.TokenRender, loopErr.ErrorSource)
+}
+
+func TestConcurrentRenderDriven(t *testing.T) {
+	_, err := gloop.NewLoop(nil, nil, gloop.Hz60Delay, gloop.Hz60Delay, gloop.WithConcurrentRender(), gloop.WithRenderDriven())
+	assert.NotNil(t, err)
+}
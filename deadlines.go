@@ -0,0 +1,34 @@
+package gloop
+
+import (
+	"time"
+)
+
+// NextSimDeadline is when the next simulate frame is scheduled.
+// It is the zero time if the loop hasn't started.
+func (l *Loop) NextSimDeadline() time.Time {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.nextSim
+}
+
+// NextRenderDeadline is when the next render frame is scheduled.
+// It is the zero time if the loop hasn't started.
+func (l *Loop) NextRenderDeadline() time.Time {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.nextRender
+}
+
+// setDeadlines records upcoming frame times.
+// Zero times are left alone.
+func (l *Loop) setDeadlines(sim, render time.Time) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if !sim.IsZero() {
+		l.nextSim = sim
+	}
+	if !render.IsZero() {
+		l.nextRender = render
+	}
+}
@@ -0,0 +1,43 @@
+package gloop_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/erinpentecost/gloop"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNextDeadlines(t *testing.T) {
+	render := func(step time.Duration) error {
+		return nil
+	}
+	simulate := func(step time.Duration) error {
+		return nil
+	}
+	loop, err := gloop.NewLoop(render, simulate, gloop.Hz60Delay, gloop.Hz60Delay)
+	assert.Nil(t, err)
+	assert.NotNil(t, loop)
+	assert.True(t, loop.NextSimDeadline().IsZero())
+	assert.True(t, loop.NextRenderDeadline().IsZero())
+
+	err = loop.Start()
+	assert.Nil(t, err)
+	time.Sleep(2 * gloop.Hz60Delay)
+
+	// Deadlines are never more than a frame ahead, and leave
+	// a few frames of slack for a busy scheduler.
+	now := time.Now()
+	sim := loop.NextSimDeadline()
+	rend := loop.NextRenderDeadline()
+	assert.True(t, sim.Sub(now) <= gloop.Hz60Delay && now.Sub(sim) < 4*gloop.Hz60Delay)
+	assert.True(t, rend.Sub(now) <= gloop.Hz60Delay && now.Sub(rend) < 4*gloop.Hz60Delay)
+
+	time.Sleep(2 * gloop.Hz60Delay)
+	assert.True(t, loop.NextSimDeadline().After(sim))
+	assert.True(t, loop.NextRenderDeadline().After(rend))
+
+	loop.Stop(nil)
+	<-loop.Done()
+	assert.Nil(t, loop.Err())
+}
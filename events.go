@@ -0,0 +1,83 @@
+package gloop
+
+import (
+	"time"
+)
+
+// eventBufferSize is how many events can queue up before
+// new ones are thrown away.
+const eventBufferSize = 64
+
+// EventKind says what happened in an Event.
+type EventKind int
+
+const (
+	// EventStarted is sent when the loop goroutine starts.
+	EventStarted EventKind = iota
+	// EventStopped is the last event sent. Err is set to Err().
+	EventStopped EventKind = iota
+	// EventSimulate is sent after each Simulate step.
+	EventSimulate EventKind = iota
+	// EventRender is sent after each render frame.
+	EventRender EventKind = iota
+	// EventOverrun is sent when a callback takes longer than its latency.
+	EventOverrun EventKind = iota
+	// EventHeartbeat is sent with each heartbeat. Sample is set.
+	EventHeartbeat EventKind = iota
+	// EventRenderDisabled is sent when WithRenderAutoDisable turns Render off.
+	// Err is set if an error was the last straw.
+	EventRenderDisabled EventKind = iota
+)
+
+// allEvents is the filter that lets every kind through.
+const allEvents = ^uint(0)
+
+// Event is something that happened in the loop.
+// Which fields are set depends on Kind.
+type Event struct {
+	Kind EventKind
+	Time time.Time
+	// Source is the callback that a simulate, render, or overrun event concerns.
+	Source TokenSource
+	// Step is the step the callback was given.
+	Step time.Duration
+	// Service is how long the callback took.
+	Service time.Duration
+	// Budget is how long the callback was supposed to take.
+	Budget time.Duration
+	// Sample is the heartbeat sample.
+	Sample LatencySample
+	// Err is why the loop stopped, or why Render was disabled.
+	Err error
+}
+
+// WithEventFilter limits Events() to the given kinds.
+// By default, every kind is sent.
+func WithEventFilter(kinds ...EventKind) Option {
+	return func(l *Loop) error {
+		l.eventFilter = 0
+		for _, kind := range kinds {
+			l.eventFilter |= 1 << uint(kind)
+		}
+		return nil
+	}
+}
+
+// Events returns a channel carrying everything that happens in the loop.
+// If the channel fills up because no one is reading, new events are
+// thrown away. The channel is closed after EventStopped is sent.
+func (l *Loop) Events() <-chan Event {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.events
+}
+
+func (l *Loop) emit(e Event) {
+	if l.eventFilter&(1<<uint(e.Kind)) == 0 {
+		return
+	}
+	select {
+	case l.events <- e:
+	default: // Throw it away if no one is listening.
+	}
+}
@@ -0,0 +1,64 @@
+package gloop_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/erinpentecost/gloop"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEventFilter(t *testing.T) {
+	simulate := func(step time.Duration) error {
+		return nil
+	}
+	loop, err := gloop.NewLoop(nil, simulate, gloop.Hz60Delay, gloop.Hz60Delay,
+		gloop.WithEventFilter(gloop.EventStopped, gloop.EventOverrun))
+	assert.Nil(t, err)
+	assert.NotNil(t, loop)
+	renders := 0
+	loop.Render = func(step time.Duration) error {
+		renders++
+		if renders == 2 {
+			// Overrun once.
+			time.Sleep(2 * gloop.Hz60Delay)
+		}
+		if renders == 5 {
+			loop.Stop(nil)
+		}
+		return nil
+	}
+	err = loop.Start()
+	assert.Nil(t, err)
+
+	kinds := make(map[gloop.EventKind]int)
+	for e := range loop.Events() {
+		kinds[e.Kind]++
+		if e.Kind == gloop.EventOverrun {
+			assert.Equal(t, gloop.TokenRender, e.Source)
+			assert.True(t, e.Service > e.Budget)
+		}
+	}
+	<-loop.Done()
+	assert.Nil(t, loop.Err())
+
+	assert.Equal(t, 2, len(kinds))
+	assert.Equal(t, 1, kinds[gloop.EventStopped])
+	assert.Equal(t, 1, kinds[gloop.EventOverrun])
+}
+
+func TestEventsStartStop(t *testing.T) {
+	loop, err := gloop.NewLoop(nil, nil, gloop.Hz60Delay, gloop.Hz60Delay,
+		gloop.WithEventFilter(gloop.EventStarted, gloop.EventStopped))
+	assert.Nil(t, err)
+	assert.NotNil(t, loop)
+	err = loop.Start()
+	assert.Nil(t, err)
+	loop.Stop(nil)
+
+	var kinds []gloop.EventKind
+	for e := range loop.Events() {
+		kinds = append(kinds, e.Kind)
+	}
+	assert.Equal(t, []gloop.EventKind{gloop.EventStarted, gloop.EventStopped}, kinds)
+}
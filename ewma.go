@@ -0,0 +1,40 @@
+package gloop
+
+import (
+	"time"
+)
+
+// WithEWMA keeps exponentially weighted moving averages of how long
+// Render and Simulate take, and reports them in each LatencySample.
+// Each new service time counts for alpha of the average, so higher
+// alphas follow sudden changes faster but smooth less.
+// alpha must be greater than 0 and at most 1.
+func WithEWMA(alpha float64) Option {
+	return func(l *Loop) error {
+		if !(alpha > 0 && alpha <= 1) {
+			return wrapLoopError(nil, TokenLoop, "EWMA alpha must be in (0, 1]")
+		}
+		l.ewmaAlpha = alpha
+		return nil
+	}
+}
+
+// ewma is an exponentially weighted moving average of durations.
+type ewma struct {
+	value  float64
+	primed bool
+}
+
+// add folds d into the average. The first value is taken as is.
+func (e *ewma) add(d time.Duration, alpha float64) {
+	if !e.primed {
+		e.value = float64(d)
+		e.primed = true
+		return
+	}
+	e.value += alpha * (float64(d) - e.value)
+}
+
+func (e *ewma) duration() time.Duration {
+	return time.Duration(e.value)
+}
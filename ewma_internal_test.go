@@ -0,0 +1,35 @@
+package gloop
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEWMATracksStepChange(t *testing.T) {
+	var average ewma
+	var window frameWindow
+	feed := func(d time.Duration, n int) {
+		for i := 0; i < n; i++ {
+			average.add(d, 0.2)
+			window.push(d)
+		}
+	}
+
+	// Settle at 1ms, then jump to 10ms.
+	feed(time.Millisecond, frameWindowSize)
+	assert.Equal(t, time.Millisecond, average.duration())
+	feed(10*time.Millisecond, 10)
+
+	var sum time.Duration
+	times := window.slice()
+	for _, d := range times {
+		sum += d
+	}
+	windowMean := sum / time.Duration(len(times))
+
+	// The window mean has barely moved, while the EWMA is most of the way there.
+	assert.True(t, windowMean < 2*time.Millisecond)
+	assert.True(t, average.duration() > 8*time.Millisecond)
+}
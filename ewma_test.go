@@ -0,0 +1,33 @@
+package gloop_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/erinpentecost/gloop"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEWMASample(t *testing.T) {
+	render := func(step time.Duration) error {
+		time.Sleep(2 * time.Millisecond)
+		return nil
+	}
+	loop, err := gloop.NewLoop(render, nil, gloop.Hz60Delay, gloop.Hz60Delay, gloop.WithEWMA(0.5))
+	assert.Nil(t, err)
+
+	assert.Nil(t, loop.Start())
+	sample := <-loop.Heartbeat()
+	loop.Stop(nil)
+	<-loop.Done()
+
+	assert.True(t, sample.RenderServiceEWMA >= 2*time.Millisecond)
+	assert.True(t, sample.SimulateServiceEWMA < time.Millisecond)
+}
+
+func TestEWMAValidation(t *testing.T) {
+	for _, alpha := range []float64{0, -0.5, 1.5} {
+		_, err := gloop.NewLoop(nil, nil, gloop.Hz60Delay, gloop.Hz60Delay, gloop.WithEWMA(alpha))
+		assert.NotNil(t, err)
+	}
+}
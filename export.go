@@ -0,0 +1,79 @@
+package gloop
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// csvHeader names the columns written by ExportCSV.
+var csvHeader = []string{"timestamp", "render_latency_ms", "simulate_latency_ms", "fps", "tps", "overruns"}
+
+// ExportCSV writes a CSV header to w, and then a row for every heartbeat
+// until stop is called or the loop finishes. Each row has the time,
+// render and simulate latency in milliseconds, render frames and
+// simulate ticks per second since the last row, and total overruns.
+// Rows are written on the loop goroutine, so w should be quick to write to.
+// Once stop returns, nothing more is written. If a write fails,
+// exporting stops.
+// It returns an error if the header can't be written, or if the
+// loop already has as many observers as WithMaxObservers allows.
+func (l *Loop) ExportCSV(w io.Writer) (stop func(), err error) {
+	out := csv.NewWriter(w)
+	var mu sync.Mutex
+	stopped := false
+	previous := l.Stats()
+	previousTime := time.Now()
+
+	// Hold mu until the header is out, so no row can beat it.
+	mu.Lock()
+	defer mu.Unlock()
+
+	unsubscribe, err := l.Subscribe(func(sample LatencySample) {
+		mu.Lock()
+		defer mu.Unlock()
+		if stopped {
+			return
+		}
+
+		now := time.Now()
+		stats := l.Stats()
+		elapsed := now.Sub(previousTime).Seconds()
+		fps := float64(stats.RenderFrames-previous.RenderFrames) / elapsed
+		tps := float64(stats.SimTicks-previous.SimTicks) / elapsed
+		previous, previousTime = stats, now
+
+		out.Write([]string{
+			now.Format(time.RFC3339Nano),
+			strconv.FormatFloat(sample.RenderLatencyMs(), 'f', -1, 64),
+			strconv.FormatFloat(sample.SimulateLatencyMs(), 'f', -1, 64),
+			strconv.FormatFloat(fps, 'f', 2, 64),
+			strconv.FormatFloat(tps, 'f', 2, 64),
+			strconv.FormatUint(stats.TotalOverruns, 10),
+		})
+		out.Flush()
+		if out.Error() != nil {
+			stopped = true
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	out.Write(csvHeader)
+	out.Flush()
+	if err := out.Error(); err != nil {
+		stopped = true
+		unsubscribe()
+		return nil, wrapLoopError(err, TokenLoop, "Failed to write CSV header")
+	}
+
+	return func() {
+		mu.Lock()
+		defer mu.Unlock()
+		stopped = true
+		unsubscribe()
+	}, nil
+}
@@ -0,0 +1,59 @@
+package gloop_test
+
+import (
+	"bytes"
+	"encoding/csv"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/erinpentecost/gloop"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExportCSV(t *testing.T) {
+	nothing := func(step time.Duration) error { return nil }
+	loop, err := gloop.NewLoop(nothing, nothing, gloop.Hz60Delay, gloop.Hz60Delay)
+	assert.Nil(t, err)
+
+	var buf bytes.Buffer
+	stop, err := loop.ExportCSV(&buf)
+	assert.Nil(t, err)
+	assert.Nil(t, loop.Start())
+	// Wait for a heartbeat. Observers run before it's sent.
+	<-loop.Heartbeat()
+	stop()
+	loop.Stop(nil)
+	<-loop.Done()
+
+	rows, err := csv.NewReader(&buf).ReadAll()
+	assert.Nil(t, err)
+	assert.True(t, len(rows) >= 2)
+	assert.Equal(t, []string{"timestamp", "render_latency_ms", "simulate_latency_ms", "fps", "tps", "overruns"}, rows[0])
+
+	row := rows[1]
+	assert.Len(t, row, 6)
+	_, err = time.Parse(time.RFC3339Nano, row[0])
+	assert.Nil(t, err)
+	for _, field := range row[1:5] {
+		_, err := strconv.ParseFloat(field, 64)
+		assert.Nil(t, err)
+	}
+	fps, _ := strconv.ParseFloat(row[3], 64)
+	assert.True(t, fps > 30)
+	_, err = strconv.ParseUint(row[5], 10, 64)
+	assert.Nil(t, err)
+}
+
+func TestExportCSVNoRoom(t *testing.T) {
+	loop, err := gloop.NewLoop(nil, nil, gloop.Hz60Delay, gloop.Hz60Delay, gloop.WithMaxObservers(1))
+	assert.Nil(t, err)
+	_, err = loop.Subscribe(func(gloop.LatencySample) {})
+	assert.Nil(t, err)
+
+	var buf bytes.Buffer
+	stop, err := loop.ExportCSV(&buf)
+	assert.NotNil(t, err)
+	assert.Nil(t, stop)
+	assert.Zero(t, buf.Len())
+}
@@ -0,0 +1,45 @@
+package gloop
+
+import (
+	"time"
+)
+
+// WithExternalTick renders once per time received on ch, instead of
+// on an internal ticker. Use it when something outside the loop sets
+// the pace, like requestAnimationFrame in a browser or a vsync signal.
+// The loop is render driven, as with WithRenderDriven: there is no
+// simulate timer, and the simulation catches up at the top of each
+// frame. Frame deltas, for both Render and the simulation catch-up,
+// are the differences between received times, not wall clock times.
+// The first frame is measured from when the loop started.
+// Closing ch stops the loop. This can't be combined with
+// WithConcurrentRender, and SetRenderRateLimit has no effect.
+func WithExternalTick(ch <-chan time.Time) Option {
+	return func(l *Loop) error {
+		if ch == nil {
+			return wrapLoopError(nil, TokenLoop, "ExternalTick can't be nil")
+		}
+		l.externalTick = ch
+		l.renderDriven = true
+		return nil
+	}
+}
+
+// tickTime is the time to measure a render frame against:
+// the received tick with WithExternalTick, and now otherwise.
+func (r *runner) tickTime(tick time.Time) time.Time {
+	if r.l.externalTick != nil {
+		return tick
+	}
+	return time.Now()
+}
+
+// renderTick runs a render frame for a tick received from rendTickC.
+// ok is false once an external tick chan is closed.
+func (r *runner) renderTick(tick time.Time, ok bool) {
+	if !ok {
+		r.l.Stop(nil)
+		return
+	}
+	r.renderFrame(tick)
+}
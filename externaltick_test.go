@@ -0,0 +1,54 @@
+package gloop_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/erinpentecost/gloop"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExternalTick(t *testing.T) {
+	const simLatency = 10 * time.Millisecond
+	const every = 25 * time.Millisecond
+	ticks := make(chan time.Time)
+	var steps []time.Duration
+	render := func(step time.Duration) error {
+		steps = append(steps, step)
+		return nil
+	}
+	simulations := 0
+	simulate := func(step time.Duration) error {
+		simulations++
+		return nil
+	}
+	loop, err := gloop.NewLoop(render, simulate, gloop.Hz60Delay, simLatency,
+		gloop.WithExternalTick(ticks), gloop.WithRenderSkipPolicy(gloop.RenderDropToLatest))
+	assert.Nil(t, err)
+
+	start := time.Now()
+	assert.Nil(t, loop.Start())
+	// The times are made up, so real time doesn't matter.
+	for i := 1; i <= 5; i++ {
+		ticks <- start.Add(time.Duration(i) * every)
+	}
+	// Closing the chan stops the loop.
+	close(ticks)
+	<-loop.Done()
+	assert.Nil(t, loop.Err())
+
+	assert.Len(t, steps, 5)
+	for _, step := range steps[1:] {
+		assert.Equal(t, every, step)
+	}
+	// 125ms of ticks, less however long Start took.
+	assert.True(t, simulations == 11 || simulations == 12, "simulations: %d", simulations)
+}
+
+func TestExternalTickValidation(t *testing.T) {
+	_, err := gloop.NewLoop(nil, nil, gloop.Hz60Delay, gloop.Hz60Delay, gloop.WithExternalTick(nil))
+	assert.NotNil(t, err)
+	_, err = gloop.NewLoop(nil, nil, gloop.Hz60Delay, gloop.Hz60Delay,
+		gloop.WithExternalTick(make(chan time.Time)), gloop.WithConcurrentRender())
+	assert.NotNil(t, err)
+}
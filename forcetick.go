@@ -0,0 +1,47 @@
+package gloop
+
+import (
+	"time"
+)
+
+// ForceTick runs one extra Simulate(SimulationLatency) on the loop
+// goroutine, between frames, and returns its error. This is handy for
+// stepping through a world frozen with PauseSimulation.
+// The extra tick doesn't change the usual simulate schedule.
+// As with any Simulate error, an error here also stops the loop.
+// ForceTick blocks until the tick has run, so don't call it from
+// inside Render or Simulate. It returns an error if the loop isn't running.
+func (l *Loop) ForceTick() error {
+	l.mu.Lock()
+	running := l.curState == stateRun
+	l.mu.Unlock()
+	if !running {
+		return wrapLoopError(nil, TokenLoop, "Loop must be running to force a tick")
+	}
+
+	reply := make(chan error, 1)
+	select {
+	case l.forced <- reply:
+		return <-reply
+	case <-l.Done():
+		return wrapLoopError(nil, TokenLoop, "Loop stopped before the tick could run")
+	}
+}
+
+// forceTick runs a single Simulate step for ForceTick.
+func (r *runner) forceTick() error {
+	l := r.l
+	start := time.Now()
+	if er := r.simulate(l.SimulationLatency); er != nil {
+		wrapped := wrapLoopError(er, TokenSimulate, "Error returned by Simulate(%s)", l.SimulationLatency.String())
+		wrapped.Misc["curTime"] = start
+		wrapped.Misc["forced"] = true
+		l.reportError(wrapped)
+		l.Stop(wrapped)
+		return wrapped
+	}
+	r.serviced(TokenSimulate, start, l.SimulationLatency, l.SimulationLatency)
+	r.publishInterpolation()
+	l.runCommits()
+	return nil
+}
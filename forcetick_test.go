@@ -0,0 +1,52 @@
+package gloop_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/erinpentecost/gloop"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestForceTick(t *testing.T) {
+	calls := 0
+	simulate := func(step time.Duration) error {
+		assert.Equal(t, gloop.Hz60Delay, step)
+		calls++
+		return nil
+	}
+	loop, err := gloop.NewLoop(nil, simulate, gloop.Hz60Delay, gloop.Hz60Delay)
+	assert.Nil(t, err)
+
+	assert.NotNil(t, loop.ForceTick())
+
+	loop.PauseSimulation()
+	assert.Nil(t, loop.Start())
+	for i := 0; i < 3; i++ {
+		assert.Nil(t, loop.ForceTick())
+	}
+	// Give the paused schedule a chance to sneak in a tick.
+	<-time.After(5 * gloop.Hz60Delay)
+	loop.Stop(nil)
+	<-loop.Done()
+
+	assert.Equal(t, 3, calls)
+	assert.Equal(t, uint64(3), loop.Stats().SimTicks)
+	assert.NotNil(t, loop.ForceTick())
+}
+
+func TestForceTickError(t *testing.T) {
+	simulate := func(step time.Duration) error {
+		return errors.New("bad tick")
+	}
+	loop, err := gloop.NewLoop(nil, simulate, gloop.Hz60Delay, gloop.Hz60Delay)
+	assert.Nil(t, err)
+
+	loop.PauseSimulation()
+	assert.Nil(t, loop.Start())
+	err = loop.ForceTick()
+	assert.NotNil(t, err)
+	<-loop.Done()
+	assert.Equal(t, err, loop.Err())
+}
@@ -0,0 +1,30 @@
+package gloop
+
+import (
+	"time"
+)
+
+// FrameReport sums up a render frame.
+type FrameReport struct {
+	// Frame is the render frame number, counting from 1.
+	Frame uint64
+	// Alpha is what Alpha() returned during the frame.
+	Alpha float64
+	// SimTicks is how many Simulate steps ran since the last render frame.
+	SimTicks int
+	// Elapsed is the real time since the last render frame.
+	Elapsed time.Duration
+	// CatchUp is true if the simulation ran several steps at once to
+	// catch up before this frame, which is when Settled() is true.
+	CatchUp bool
+}
+
+// WithFrameReport calls fn once after each render frame, on the
+// goroutine that renders, with a summary of the frame.
+// It is handy for profiling overlays. fn should return quickly.
+func WithFrameReport(fn func(FrameReport)) Option {
+	return func(l *Loop) error {
+		l.frameReport = fn
+		return nil
+	}
+}
@@ -0,0 +1,87 @@
+package gloop_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/erinpentecost/gloop"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFrameReport(t *testing.T) {
+	var reports []gloop.FrameReport
+	report := func(r gloop.FrameReport) {
+		reports = append(reports, r)
+	}
+	loop, err := gloop.NewLoop(nil, nil, gloop.Hz60Delay, gloop.Hz60Delay/3, gloop.WithFrameReport(report))
+	assert.Nil(t, err)
+
+	// What each report should say about simulate calls.
+	var expected []int
+	simulations, total := 0, 0
+	loop.Simulate = func(step time.Duration) error {
+		simulations++
+		total++
+		if total == 5 {
+			// Stall so the next frame has to catch up.
+			time.Sleep(3 * gloop.Hz60Delay)
+		}
+		return nil
+	}
+	loop.Render = func(step time.Duration) error {
+		expected = append(expected, simulations)
+		simulations = 0
+		if len(expected) == 10 {
+			loop.Stop(nil)
+		}
+		return nil
+	}
+
+	assert.Nil(t, loop.Start())
+	<-loop.Done()
+	assert.Nil(t, loop.Err())
+
+	// The last render stopped the loop, so it has no report.
+	assert.Len(t, reports, 9)
+	catchUps := 0
+	for i, r := range reports {
+		assert.Equal(t, uint64(i+1), r.Frame)
+		assert.Equal(t, expected[i], r.SimTicks)
+		assert.True(t, r.Alpha >= 0 && r.Alpha <= 1)
+		assert.True(t, r.Elapsed > 0)
+		if r.CatchUp {
+			catchUps++
+			assert.True(t, r.SimTicks > 1)
+		}
+	}
+	assert.True(t, catchUps >= 1)
+}
+
+func TestFrameReportCountsFrames(t *testing.T) {
+	var reports []gloop.FrameReport
+	report := func(r gloop.FrameReport) {
+		reports = append(reports, r)
+	}
+	renders := 0
+	render := func(step time.Duration) error {
+		renders++
+		if renders == 3 {
+			// Stall, so the next frame renders several times.
+			time.Sleep(4 * gloop.Hz60Delay)
+		}
+		return nil
+	}
+	loop, err := gloop.NewLoop(render, nil, gloop.Hz60Delay, gloop.Hz60Delay,
+		gloop.WithRenderSkipPolicy(gloop.RenderNeverDrop), gloop.WithFrameReport(report))
+	assert.Nil(t, err)
+
+	assert.Nil(t, loop.Start())
+	time.Sleep(200 * time.Millisecond)
+	loop.Stop(nil)
+	<-loop.Done()
+
+	assert.True(t, uint64(renders) > uint64(len(reports)), "renders: %d, reports: %d", renders, len(reports))
+	for i, r := range reports {
+		assert.Equal(t, uint64(i+1), r.Frame)
+	}
+}
@@ -0,0 +1,51 @@
+package gloop
+
+import (
+	"time"
+)
+
+// frameWindowSize is how many render frames RecentFrameTimes remembers.
+const frameWindowSize = 120
+
+// frameWindow is a ring buffer of the most recent frame times.
+type frameWindow struct {
+	times [frameWindowSize]time.Duration
+	// next is where the next frame time goes.
+	next int
+	// count is how many slots are filled.
+	count int
+}
+
+// push records d, overwriting the oldest frame time once full.
+func (w *frameWindow) push(d time.Duration) {
+	w.times[w.next] = d
+	w.next = (w.next + 1) % len(w.times)
+	if w.count < len(w.times) {
+		w.count++
+	}
+}
+
+// slice copies the frame times out, oldest first.
+func (w *frameWindow) slice() []time.Duration {
+	out := make([]time.Duration, 0, w.count)
+	oldest := (w.next - w.count + len(w.times)) % len(w.times)
+	for i := 0; i < w.count; i++ {
+		out = append(out, w.times[(oldest+i)%len(w.times)])
+	}
+	return out
+}
+
+// RecentFrameTimes returns how long each of the last 120 render frames
+// took to service, oldest first. Plot it for an on-screen frame graph.
+// The slice is a copy, and is shorter until 120 frames have rendered.
+func (l *Loop) RecentFrameTimes() []time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.frameTimes.slice()
+}
+
+func (l *Loop) recordFrameTime(d time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.frameTimes.push(d)
+}
@@ -0,0 +1,29 @@
+package gloop
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFrameWindow(t *testing.T) {
+	var w frameWindow
+	assert.Empty(t, w.slice())
+
+	w.push(1)
+	w.push(2)
+	w.push(3)
+	assert.Equal(t, []time.Duration{1, 2, 3}, w.slice())
+
+	// Wrap around a couple of times.
+	for i := 4; i <= 2*frameWindowSize+10; i++ {
+		w.push(time.Duration(i))
+	}
+	got := w.slice()
+	assert.Len(t, got, frameWindowSize)
+	first := time.Duration(2*frameWindowSize + 10 - frameWindowSize + 1)
+	for i, d := range got {
+		assert.Equal(t, first+time.Duration(i), d)
+	}
+}
@@ -0,0 +1,34 @@
+package gloop_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/erinpentecost/gloop"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecentFrameTimes(t *testing.T) {
+	sleeps := []time.Duration{2 * time.Millisecond, 8 * time.Millisecond, 4 * time.Millisecond}
+	frame := 0
+	render := func(step time.Duration) error {
+		time.Sleep(sleeps[frame])
+		frame++
+		return nil
+	}
+	loop, err := gloop.NewLoop(render, nil, gloop.Hz60Delay, gloop.Hz60Delay, gloop.WithStepRender())
+	assert.Nil(t, err)
+	assert.Empty(t, loop.RecentFrameTimes())
+
+	for range sleeps {
+		assert.Nil(t, loop.StepN(1))
+	}
+
+	times := loop.RecentFrameTimes()
+	assert.Len(t, times, len(sleeps))
+	for i, d := range times {
+		assert.True(t, d >= sleeps[i])
+	}
+	// Oldest first.
+	assert.True(t, times[1] > times[0]+4*time.Millisecond)
+}
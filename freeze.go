@@ -0,0 +1,114 @@
+package gloop
+
+import (
+	"time"
+)
+
+// FreezeFrame stops the loop once the frame in progress is done, for
+// stepping through frames in a debugger. Neither Simulate nor Render is
+// called until AdvanceFrame or Unfreeze, and, as with Suspend, time
+// doesn't build up while frozen. Heartbeats keep coming.
+func (l *Loop) FreezeFrame() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.frozen = true
+}
+
+// Unfreeze undoes FreezeFrame. The loop carries on from where it was,
+// without catching up on the frozen time.
+func (l *Loop) Unfreeze() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.frozen {
+		l.frozen = false
+		l.resumed()
+	}
+}
+
+// Frozen is true between FreezeFrame and Unfreeze.
+func (l *Loop) Frozen() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.frozen
+}
+
+// halted is true if neither Simulate nor Render should run on schedule.
+func (l *Loop) halted() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.suspended || l.frozen
+}
+
+// AdvanceFrame runs exactly one whole frame on a frozen loop: it moves
+// simulation time on by RenderLatency, runs the fixed Simulate steps
+// that are then due, and renders once with a step of RenderLatency.
+// Leftover simulation time carries over to the next frame, just as it
+// would while running, so the simulation stays consistent. If the
+// simulation is paused, only the render runs, and if rendering is
+// disabled, only the simulation does.
+// It returns the first error, which also stops the loop, unless
+// WithRenderAutoDisable is set and the error came from Render.
+// Like ForceTick, it blocks until the frame has run, so don't call it
+// from inside Render or Simulate. It returns an error if the loop isn't
+// running or isn't frozen.
+func (l *Loop) AdvanceFrame() error {
+	l.mu.Lock()
+	running, frozen := l.curState == stateRun, l.frozen
+	l.mu.Unlock()
+	if !running {
+		return wrapLoopError(nil, TokenLoop, "Loop must be running to advance a frame")
+	}
+	if !frozen {
+		return wrapLoopError(nil, TokenLoop, "Loop must be frozen to advance a frame")
+	}
+
+	reply := make(chan error, 1)
+	select {
+	case l.advance <- reply:
+		return <-reply
+	case <-l.Done():
+		return wrapLoopError(nil, TokenLoop, "Loop stopped before the frame could run")
+	}
+}
+
+// advanceFrame runs one frame for AdvanceFrame.
+func (r *runner) advanceFrame() error {
+	l := r.l
+	frame := l.RenderLatency
+	start := time.Now()
+	if !l.SimulationPaused() {
+		r.simAccumulator += frame
+		for step := r.simStep(); r.simAccumulator >= step; step = r.simStep() {
+			stepStart := time.Now()
+			if er := r.simulate(step); er != nil {
+				wrapped := wrapLoopError(er, TokenSimulate, "Error returned by Simulate(%s)", step.String())
+				wrapped.Misc["curTime"] = stepStart
+				l.reportError(wrapped)
+				l.Stop(wrapped)
+				return wrapped
+			}
+			r.serviced(TokenSimulate, stepStart, step, step)
+			r.simAccumulator -= step
+			r.advanceSimStep()
+		}
+		r.publishInterpolation()
+		l.setAlpha(r.simAccumulator, l.SimulationLatency)
+		l.runCommits()
+	}
+	if l.RenderDisabled() {
+		return nil
+	}
+
+	l.frameMu.Lock()
+	defer l.frameMu.Unlock()
+	if er := r.renderStep(frame, start.Add(frame)); er != nil {
+		if l.renderStrikes == 0 {
+			l.Stop(er)
+		} else {
+			r.renderStrike(true, er)
+		}
+		return er
+	}
+	r.renderFrames++
+	return nil
+}
@@ -0,0 +1,64 @@
+package gloop_test
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/erinpentecost/gloop"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFreezeFrame(t *testing.T) {
+	const simLatency = 5 * time.Millisecond
+	const renderLatency = 2 * simLatency
+	var renders, simulations int32
+	var renderSteps []time.Duration
+	render := func(step time.Duration) error {
+		atomic.AddInt32(&renders, 1)
+		renderSteps = append(renderSteps, step)
+		return nil
+	}
+	simulate := func(step time.Duration) error {
+		atomic.AddInt32(&simulations, 1)
+		return nil
+	}
+	loop, err := gloop.NewLoop(render, simulate, renderLatency, simLatency)
+	assert.Nil(t, err)
+
+	// Can't advance a loop that isn't running.
+	assert.NotNil(t, loop.AdvanceFrame())
+	assert.Nil(t, loop.Start())
+	<-loop.Ready()
+	// Or one that isn't frozen.
+	assert.NotNil(t, loop.AdvanceFrame())
+
+	loop.FreezeFrame()
+	assert.True(t, loop.Frozen())
+	// Let the frame in progress finish.
+	time.Sleep(3 * renderLatency)
+	rendersBefore := atomic.LoadInt32(&renders)
+	simulationsBefore := atomic.LoadInt32(&simulations)
+	time.Sleep(5 * renderLatency)
+	assert.Equal(t, rendersBefore, atomic.LoadInt32(&renders))
+	assert.Equal(t, simulationsBefore, atomic.LoadInt32(&simulations))
+
+	for i := 0; i < 3; i++ {
+		assert.Nil(t, loop.AdvanceFrame())
+	}
+	time.Sleep(5 * renderLatency)
+	// Exactly three frames, each with one frame's worth of simulation.
+	assert.Equal(t, rendersBefore+3, atomic.LoadInt32(&renders))
+	assert.Equal(t, simulationsBefore+6, atomic.LoadInt32(&simulations))
+	assert.Equal(t, []time.Duration{renderLatency, renderLatency, renderLatency},
+		renderSteps[rendersBefore:])
+
+	loop.Unfreeze()
+	assert.False(t, loop.Frozen())
+	time.Sleep(5 * renderLatency)
+	assert.True(t, atomic.LoadInt32(&renders) > rendersBefore+3)
+
+	loop.Stop(nil)
+	<-loop.Done()
+	assert.Nil(t, loop.Err())
+}
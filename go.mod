@@ -1,3 +1,13 @@
 module github.com/erinpentecost/gloop
 
-require github.com/stretchr/testify v1.3.0
+go 1.18
+
+require (
+	github.com/google/pprof v0.0.0-20221118152302-e6195bd50e26
+	github.com/stretchr/testify v1.3.0
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.0 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+)
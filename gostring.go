@@ -0,0 +1,30 @@
+package gloop
+
+import (
+	"fmt"
+)
+
+func (s state) String() string {
+	switch s {
+	case stateInit:
+		return "init"
+	case stateRun:
+		return "running"
+	case stateStop:
+		return "stopped"
+	}
+	return fmt.Sprintf("state(%d)", int(s))
+}
+
+// GoString is a short description of the loop for %#v, with its state,
+// latencies and lifetime counts, instead of every chan and mutex inside.
+func (l *Loop) GoString() string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	desc := fmt.Sprintf("&gloop.Loop{State: %s, RenderLatency: %s, SimulationLatency: %s, SimTicks: %d, RenderFrames: %d",
+		l.curState, l.RenderLatency, l.SimulationLatency, l.stats.SimTicks, l.stats.RenderFrames)
+	if l.err != nil {
+		desc += fmt.Sprintf(", Err: %q", l.err.Error())
+	}
+	return desc + "}"
+}
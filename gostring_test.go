@@ -0,0 +1,30 @@
+package gloop_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/erinpentecost/gloop"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGoString(t *testing.T) {
+	loop, err := gloop.NewLoop(nil, nil, 10*time.Millisecond, 5*time.Millisecond)
+	assert.Nil(t, err)
+	assert.Equal(t,
+		"&gloop.Loop{State: init, RenderLatency: 10ms, SimulationLatency: 5ms, SimTicks: 0, RenderFrames: 0}",
+		fmt.Sprintf("%#v", loop))
+
+	assert.Nil(t, loop.StepN(3))
+	assert.Nil(t, loop.Start())
+	assert.Contains(t, fmt.Sprintf("%#v", loop), "State: running")
+
+	loop.Stop(errors.New("quit"))
+	<-loop.Done()
+	desc := fmt.Sprintf("%#v", loop)
+	assert.Contains(t, desc, "State: stopped")
+	assert.Contains(t, desc, `Err: "quit"`)
+	assert.NotContains(t, desc, "chan")
+}
@@ -0,0 +1,78 @@
+package gloop
+
+import (
+	"sync/atomic"
+)
+
+// WithRaceGuard turns on checks for Render and Simulate touching the
+// same state at the same time, which can happen with WithConcurrentRender.
+// Wrap shared state with Guard to have it checked. When the guard trips,
+// the loop stops with a LoopError saying which side broke the rules.
+// Without this option, Guard does no checking at all.
+func WithRaceGuard() Option {
+	return func(l *Loop) error {
+		l.raceGuard = true
+		return nil
+	}
+}
+
+// Guarded is state that Simulate writes and Render reads.
+type Guarded[T any] struct {
+	l     *Loop
+	value *T
+}
+
+// Guard wraps value so that WithRaceGuard can check how it is used.
+func Guard[T any](l *Loop, value *T) *Guarded[T] {
+	return &Guarded[T]{l: l, value: value}
+}
+
+// Read returns the value for Render to read.
+// With WithRaceGuard, if Simulate is running on another goroutine,
+// the loop stops and Read returns the LoopError it stopped with
+// instead of the value. Return that error from the callback.
+// Simulate may Read its own state.
+func (g *Guarded[T]) Read() (*T, error) {
+	l := g.l
+	if l.raceGuard && runningElsewhere(&l.simulating) {
+		err := wrapLoopError(nil, TokenRender, "Render read guarded state while Simulate was running")
+		l.Stop(err)
+		return nil, err
+	}
+	return g.value, nil
+}
+
+// Write returns the value for Simulate to change.
+// With WithRaceGuard, if Render is running on another goroutine,
+// the loop stops and Write returns the LoopError it stopped with
+// instead of the value. Return that error from the callback.
+func (g *Guarded[T]) Write() (*T, error) {
+	l := g.l
+	if l.raceGuard && runningElsewhere(&l.rendering) {
+		err := wrapLoopError(nil, TokenSimulate, "Simulate wrote guarded state while Render was running")
+		l.Stop(err)
+		return nil, err
+	}
+	return g.value, nil
+}
+
+// runningElsewhere is true if the callback that owner tracks
+// is running on a goroutine other than the caller's.
+func runningElsewhere(owner *uint64) bool {
+	id := atomic.LoadUint64(owner)
+	return id != 0 && id != goid()
+}
+
+// enterGuard marks the callback for region as running on the
+// calling goroutine, until the returned func is called.
+func (l *Loop) enterGuard(region string) func() {
+	if !l.raceGuard {
+		return func() {}
+	}
+	owner := &l.rendering
+	if region == simulateRegion {
+		owner = &l.simulating
+	}
+	atomic.StoreUint64(owner, goid())
+	return func() { atomic.StoreUint64(owner, 0) }
+}
@@ -0,0 +1,84 @@
+package gloop_test
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/erinpentecost/gloop"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRaceGuardTrips(t *testing.T) {
+	loop, err := gloop.NewLoop(nil, nil, gloop.Hz60Delay, gloop.Hz60Delay, gloop.WithConcurrentRender(), gloop.WithRaceGuard())
+	assert.Nil(t, err)
+
+	positions := []float64{0}
+	guard := gloop.Guard(loop, &positions)
+	var withheld int32
+	loop.Simulate = func(step time.Duration) error {
+		if _, err := guard.Write(); err != nil {
+			return err
+		}
+		// A long tick, so render is sure to run during it.
+		time.Sleep(5 * gloop.Hz60Delay)
+		return nil
+	}
+	loop.Render = func(step time.Duration) error {
+		// Reading the positions here would be a data race,
+		// so the guard doesn't hand them out.
+		if _, err := guard.Read(); err != nil {
+			atomic.StoreInt32(&withheld, 1)
+			return err
+		}
+		return nil
+	}
+
+	assert.Nil(t, loop.Start())
+	select {
+	case <-loop.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatal("the guard didn't trip")
+	}
+	loopErr, ok := loop.Err().(gloop.LoopError)
+	assert.True(t, ok)
+	assert.Equal(t, gloop.TokenRender, loopErr.ErrorSource)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&withheld))
+}
+
+func TestRaceGuardSerial(t *testing.T) {
+	loop, err := gloop.NewLoop(nil, nil, gloop.Hz60Delay, gloop.Hz60Delay/2, gloop.WithRaceGuard())
+	assert.Nil(t, err)
+
+	positions := []float64{0}
+	guard := gloop.Guard(loop, &positions)
+	renders := 0
+	loop.Simulate = func(step time.Duration) error {
+		// Simulate can read its own state, too.
+		if _, err := guard.Read(); err != nil {
+			return err
+		}
+		state, err := guard.Write()
+		if err != nil {
+			return err
+		}
+		(*state)[0] += step.Seconds()
+		return nil
+	}
+	loop.Render = func(step time.Duration) error {
+		state, err := guard.Read()
+		if err != nil {
+			return err
+		}
+		_ = (*state)[0]
+		renders++
+		if renders == 10 {
+			loop.Stop(nil)
+		}
+		return nil
+	}
+
+	assert.Nil(t, loop.Start())
+	<-loop.Done()
+	assert.Nil(t, loop.Err())
+}
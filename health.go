@@ -0,0 +1,103 @@
+package gloop
+
+import (
+	"fmt"
+)
+
+// healthWindowSize is how many recent callbacks HealthStatus looks at.
+const healthWindowSize = 60
+
+// defaultHealthRed is the share of recent callbacks that have to
+// overrun for the loop to be HealthRed.
+const defaultHealthRed = 0.5
+
+// Health is a rough classification of how well the loop is keeping up.
+type Health int
+
+const (
+	// HealthGreen is a loop whose callbacks are within budget.
+	HealthGreen Health = iota
+	// HealthYellow is a loop with occasional overruns.
+	HealthYellow
+	// HealthRed is a loop with sustained overruns, which may be
+	// spiraling.
+	HealthRed
+)
+
+// String is the name of the status, like "green".
+func (h Health) String() string {
+	switch h {
+	case HealthGreen:
+		return "green"
+	case HealthYellow:
+		return "yellow"
+	case HealthRed:
+		return "red"
+	}
+	return fmt.Sprintf("Health(%d)", int(h))
+}
+
+// healthWindow is a ring buffer of whether recent callbacks overran.
+type healthWindow struct {
+	overran [healthWindowSize]bool
+	next    int
+	count   int
+	// overruns is how many of the filled slots are true.
+	overruns int
+}
+
+// push records a callback, overwriting the oldest once full.
+func (w *healthWindow) push(overrun bool) {
+	if w.count == len(w.overran) && w.overran[w.next] {
+		w.overruns--
+	}
+	w.overran[w.next] = overrun
+	if overrun {
+		w.overruns++
+	}
+	w.next = (w.next + 1) % len(w.overran)
+	if w.count < len(w.overran) {
+		w.count++
+	}
+}
+
+// share is the fraction of recorded callbacks that overran.
+func (w *healthWindow) share() float64 {
+	if w.count == 0 {
+		return 0
+	}
+	return float64(w.overruns) / float64(w.count)
+}
+
+// WithHealthThresholds sets how HealthStatus classifies the loop.
+// It is HealthYellow once more than yellow of the last 60 callbacks
+// overran their latency, and HealthRed once red or more of them did.
+// Both are fractions, so the defaults of 0 and 0.5 mean any overrun
+// makes the loop yellow, and overrunning half the time makes it red.
+func WithHealthThresholds(yellow, red float64) Option {
+	return func(l *Loop) error {
+		if !(yellow >= 0 && yellow < red && red <= 1) {
+			return wrapLoopError(nil, TokenLoop, "Health thresholds must have 0 <= yellow < red <= 1")
+		}
+		l.healthYellow = yellow
+		l.healthRed = red
+		return nil
+	}
+}
+
+// HealthStatus classifies how well the loop has kept up over the last
+// 60 Simulate and Render calls, for a dashboard that wants a traffic
+// light rather than latencies. See WithHealthThresholds.
+// It is HealthGreen before any callback has run.
+func (l *Loop) HealthStatus() Health {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	share := l.health.share()
+	switch {
+	case share >= l.healthRed:
+		return HealthRed
+	case share > l.healthYellow:
+		return HealthYellow
+	}
+	return HealthGreen
+}
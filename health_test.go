@@ -0,0 +1,67 @@
+package gloop_test
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/erinpentecost/gloop"
+	"github.com/stretchr/testify/assert"
+)
+
+// waitForHealth polls until loop reports want, or gives up.
+func waitForHealth(loop *gloop.Loop, want gloop.Health) bool {
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		if loop.HealthStatus() == want {
+			return true
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	return false
+}
+
+func TestHealthStatus(t *testing.T) {
+	const latency = 10 * time.Millisecond
+	var slow int32
+	simulate := func(step time.Duration) error {
+		if atomic.LoadInt32(&slow) == 1 {
+			time.Sleep(2 * latency)
+		}
+		return nil
+	}
+	// Render rarely, so nearly every callback is a simulate step.
+	loop, err := gloop.NewLoop(nil, simulate, time.Second, latency)
+	assert.Nil(t, err)
+	assert.Equal(t, gloop.HealthGreen, loop.HealthStatus())
+
+	assert.Nil(t, loop.Start())
+	<-loop.Ready()
+	time.Sleep(10 * latency)
+	assert.Equal(t, gloop.HealthGreen, loop.HealthStatus())
+
+	atomic.StoreInt32(&slow, 1)
+	assert.True(t, waitForHealth(loop, gloop.HealthRed))
+
+	loop.Stop(nil)
+	<-loop.Done()
+	assert.Nil(t, loop.Err())
+}
+
+func TestHealthThresholds(t *testing.T) {
+	_, err := gloop.NewLoop(nil, nil, gloop.Hz60Delay, gloop.Hz60Delay,
+		gloop.WithHealthThresholds(0.5, 0.5))
+	assert.NotNil(t, err)
+	_, err = gloop.NewLoop(nil, nil, gloop.Hz60Delay, gloop.Hz60Delay,
+		gloop.WithHealthThresholds(0.1, 1.5))
+	assert.NotNil(t, err)
+	_, err = gloop.NewLoop(nil, nil, gloop.Hz60Delay, gloop.Hz60Delay,
+		gloop.WithHealthThresholds(0.1, 0.9))
+	assert.Nil(t, err)
+}
+
+func TestHealthString(t *testing.T) {
+	assert.Equal(t, "green", gloop.HealthGreen.String())
+	assert.Equal(t, "yellow", gloop.HealthYellow.String())
+	assert.Equal(t, "red", gloop.HealthRed.String())
+}
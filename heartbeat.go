@@ -0,0 +1,49 @@
+package gloop
+
+// WithHeartbeatBuffer gives the heartbeat channel room for n samples,
+// so samples taken while no one is listening are kept instead of
+// thrown away. Once the buffer is full, new samples are thrown away,
+// and counted in the Dropped field of the next sample that fits.
+// By default the channel is unbuffered.
+func WithHeartbeatBuffer(n int) Option {
+	return func(l *Loop) error {
+		if n <= 0 {
+			return wrapLoopError(nil, TokenLoop, "HeartbeatBuffer can't be lte 0")
+		}
+		l.heartbeat = make(chan LatencySample, n)
+		return nil
+	}
+}
+
+// WithHeartbeatWarmupSkip throws away the first n heartbeat samples,
+// which are noisy while caches warm up and pages fault in. Skipped
+// samples still reset the utilization window, but they aren't sent
+// on Heartbeat, to observers, as events, or kept for TryHeartbeat.
+func WithHeartbeatWarmupSkip(n int) Option {
+	return func(l *Loop) error {
+		if n <= 0 {
+			return wrapLoopError(nil, TokenLoop, "HeartbeatWarmupSkip can't be lte 0")
+		}
+		l.warmupBeats = n
+		return nil
+	}
+}
+
+// DrainHeartbeat returns every sample waiting on the heartbeat channel,
+// oldest first, without blocking. It returns an empty slice if there are none.
+// This is most useful with WithHeartbeatBuffer.
+func (l *Loop) DrainHeartbeat() []LatencySample {
+	heartbeat := l.Heartbeat()
+	samples := []LatencySample{}
+	for {
+		select {
+		case sample, ok := <-heartbeat:
+			if !ok {
+				return samples
+			}
+			samples = append(samples, sample)
+		default:
+			return samples
+		}
+	}
+}
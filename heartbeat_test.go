@@ -0,0 +1,115 @@
+package gloop_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/erinpentecost/gloop"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDrainHeartbeat(t *testing.T) {
+	nothing := func(step time.Duration) error { return nil }
+	loop, err := gloop.NewLoop(nothing, nothing, gloop.Hz60Delay, gloop.Hz60Delay, gloop.WithHeartbeatBuffer(4))
+	assert.Nil(t, err)
+
+	samples := loop.DrainHeartbeat()
+	assert.NotNil(t, samples)
+	assert.Empty(t, samples)
+
+	assert.Nil(t, loop.Start())
+	// Heartbeats come once a second.
+	<-time.After(1500 * time.Millisecond)
+	loop.Stop(nil)
+	<-loop.Done()
+
+	samples = loop.DrainHeartbeat()
+	assert.NotEmpty(t, samples)
+	assert.Empty(t, loop.DrainHeartbeat())
+}
+
+func TestHeartbeatBufferValidation(t *testing.T) {
+	_, err := gloop.NewLoop(nil, nil, gloop.Hz60Delay, gloop.Hz60Delay, gloop.WithHeartbeatBuffer(0))
+	assert.NotNil(t, err)
+}
+
+func TestHeartbeatWarmupSkip(t *testing.T) {
+	nothing := func(step time.Duration) error { return nil }
+	loop, err := gloop.NewLoop(nothing, nothing, gloop.Hz60Delay, gloop.Hz60Delay,
+		gloop.WithHeartbeatBuffer(4), gloop.WithHeartbeatWarmupSkip(1))
+	assert.Nil(t, err)
+
+	assert.Nil(t, loop.Start())
+	// The first heartbeat, at one second, is skipped.
+	<-time.After(1500 * time.Millisecond)
+	assert.Empty(t, loop.DrainHeartbeat())
+	_, ok := loop.TryHeartbeat()
+	assert.False(t, ok)
+
+	// The second one is delivered.
+	<-time.After(time.Second)
+	loop.Stop(nil)
+	<-loop.Done()
+	assert.Len(t, loop.DrainHeartbeat(), 1)
+	_, ok = loop.TryHeartbeat()
+	assert.True(t, ok)
+
+	_, err = gloop.NewLoop(nil, nil, gloop.Hz60Delay, gloop.Hz60Delay, gloop.WithHeartbeatWarmupSkip(0))
+	assert.NotNil(t, err)
+}
+
+func TestHeartbeatOkAfterStop(t *testing.T) {
+	nothing := func(step time.Duration) error { return nil }
+	loop, err := gloop.NewLoop(nothing, nothing, gloop.Hz60Delay, gloop.Hz60Delay, gloop.WithHeartbeatBuffer(1))
+	assert.Nil(t, err)
+
+	assert.Nil(t, loop.Start())
+	<-time.After(1500 * time.Millisecond)
+	loop.Stop(nil)
+	<-loop.Done()
+
+	// The last real sample is still there, and says so.
+	sample, ok := <-loop.Heartbeat()
+	assert.True(t, ok)
+	assert.NotZero(t, sample.RenderLatency)
+
+	// After that, the channel is closed, and the zero sample isn't ok.
+	sample, ok = <-loop.Heartbeat()
+	assert.False(t, ok)
+	assert.Equal(t, gloop.LatencySample{}, sample)
+}
+
+func TestHeartbeatDropped(t *testing.T) {
+	nothing := func(step time.Duration) error { return nil }
+	small, err := gloop.NewLoop(nothing, nothing, gloop.Hz60Delay, gloop.Hz60Delay, gloop.WithHeartbeatBuffer(1))
+	assert.Nil(t, err)
+	large, err := gloop.NewLoop(nothing, nothing, gloop.Hz60Delay, gloop.Hz60Delay, gloop.WithHeartbeatBuffer(3))
+	assert.Nil(t, err)
+	assert.Nil(t, small.Start())
+	assert.Nil(t, large.Start())
+
+	// A slow consumer, that only checks in after two heartbeats.
+	<-time.After(2500 * time.Millisecond)
+	smallFirst := small.DrainHeartbeat()
+	largeFirst := large.DrainHeartbeat()
+	<-time.After(time.Second)
+	small.Stop(nil)
+	large.Stop(nil)
+	<-small.Done()
+	<-large.Done()
+	smallSecond := small.DrainHeartbeat()
+	largeSecond := large.DrainHeartbeat()
+
+	// The larger buffer kept every sample.
+	assert.Len(t, largeFirst, 2)
+	assert.Len(t, largeSecond, 1)
+	for _, sample := range append(largeFirst, largeSecond...) {
+		assert.Equal(t, uint64(0), sample.Dropped)
+	}
+
+	// The smaller one lost the second, and said so on the third.
+	assert.Len(t, smallFirst, 1)
+	assert.Equal(t, uint64(0), smallFirst[0].Dropped)
+	assert.Len(t, smallSecond, 1)
+	assert.Equal(t, uint64(1), smallSecond[0].Dropped)
+}
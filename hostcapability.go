@@ -0,0 +1,87 @@
+package gloop
+
+import (
+	"context"
+	"time"
+)
+
+// hostSamples is how many times MeasureHostCapability tries each thing.
+const hostSamples = 50
+
+// HostCapability describes how precisely this machine keeps time.
+type HostCapability struct {
+	// ClockResolution is the smallest step time.Now was seen to take.
+	ClockResolution time.Duration
+	// TimerResolution is the median time a timer set for 1µs took to fire.
+	TimerResolution time.Duration
+	// SleepOvershoot is the median time a 1ms sleep ran over by.
+	SleepOvershoot time.Duration
+	// MinLatency is the 95th percentile time a timer set for 1µs took
+	// to fire. A loop latency below this can't be held reliably, so
+	// pick RenderLatency and SimulationLatency well above it.
+	MinLatency time.Duration
+}
+
+// MeasureHostCapability measures the clock, timers and sleeps on this
+// machine, to help pick rates before creating a loop. It takes tens of
+// milliseconds, or longer on hosts with coarse timers. Timer resolution
+// is raised while measuring, just as it is while a loop runs.
+// It returns an error if ctx is done first.
+func MeasureHostCapability(ctx context.Context) (HostCapability, error) {
+	beginTimerPeriod()
+	defer endTimerPeriod()
+
+	var host HostCapability
+	cancelled := func() error {
+		if err := ctx.Err(); err != nil {
+			return wrapLoopError(err, TokenLoop, "MeasureHostCapability was cancelled")
+		}
+		return nil
+	}
+
+	// The clock: spin until time.Now moves, a few times over.
+	for i := 0; i < hostSamples; i++ {
+		start := time.Now()
+		now := time.Now()
+		for now.Equal(start) {
+			now = time.Now()
+		}
+		if step := now.Sub(start); host.ClockResolution == 0 || step < host.ClockResolution {
+			host.ClockResolution = step
+		}
+	}
+
+	// Timers set for almost nothing.
+	fires := make([]time.Duration, 0, hostSamples)
+	timer := time.NewTimer(time.Hour)
+	defer timer.Stop()
+	for i := 0; i < hostSamples; i++ {
+		if err := cancelled(); err != nil {
+			return HostCapability{}, err
+		}
+		start := time.Now()
+		timer.Reset(time.Microsecond)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			return HostCapability{}, cancelled()
+		}
+		fires = append(fires, time.Since(start))
+	}
+	fired := percentiles(fires)
+	host.TimerResolution = fired.P50
+	host.MinLatency = fired.P95
+
+	// Sleeps, which may be less precise than timers.
+	overshoots := make([]time.Duration, 0, hostSamples)
+	for i := 0; i < hostSamples; i++ {
+		if err := cancelled(); err != nil {
+			return HostCapability{}, err
+		}
+		start := time.Now()
+		time.Sleep(time.Millisecond)
+		overshoots = append(overshoots, time.Since(start)-time.Millisecond)
+	}
+	host.SleepOvershoot = percentiles(overshoots).P50
+	return host, nil
+}
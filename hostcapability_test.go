@@ -0,0 +1,28 @@
+package gloop_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/erinpentecost/gloop"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMeasureHostCapability(t *testing.T) {
+	host, err := gloop.MeasureHostCapability(context.Background())
+	assert.Nil(t, err)
+
+	assert.True(t, host.ClockResolution > 0, "clock: %s", host.ClockResolution)
+	assert.True(t, host.TimerResolution > 0, "timer: %s", host.TimerResolution)
+	assert.True(t, host.MinLatency >= host.TimerResolution)
+	assert.True(t, host.SleepOvershoot >= 0, "overshoot: %s", host.SleepOvershoot)
+	// Any host that can run a game loop can do better than 60 Hz.
+	assert.True(t, host.MinLatency < gloop.Hz60Delay, "min latency: %s", host.MinLatency)
+}
+
+func TestMeasureHostCapabilityCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := gloop.MeasureHostCapability(ctx)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,62 @@
+package gloop
+
+// WithHysteresis sets how sure the loop has to be before an adaptive
+// feature acts: trip frames in a row must overrun, or fail, before it
+// kicks in, and clear healthy frames in a row must pass before it backs
+// off. A single noisy frame resets the count either way.
+// Every adaptive feature uses these counts. For WithRenderAutoDisable,
+// trip replaces the n passed to it, and clear is unused because a
+// disabled Render isn't called again. For WithIdleRender, a frame with
+// nothing new to draw counts as a bad one.
+func WithHysteresis(trip, clear int) Option {
+	return func(l *Loop) error {
+		if trip <= 0 {
+			return wrapLoopError(nil, TokenLoop, "Hysteresis trip can't be lte 0")
+		}
+		if clear <= 0 {
+			return wrapLoopError(nil, TokenLoop, "Hysteresis clear can't be lte 0")
+		}
+		l.adaptTrip = trip
+		l.adaptClear = clear
+		return nil
+	}
+}
+
+// hysteresis decides when an adaptive action should be in effect,
+// so that it can't flap on a single noisy frame.
+type hysteresis struct {
+	// trip and clear are the counts from WithHysteresis.
+	// A clear of 0 means the action never backs off.
+	trip, clear int
+	// bad and good are the frames in a row of each kind so far.
+	bad, good int
+	tripped   bool
+}
+
+// newHysteresis uses the loop's counts, or trip and clear if
+// WithHysteresis wasn't used.
+func (l *Loop) newHysteresis(trip, clear int) hysteresis {
+	if l.adaptTrip > 0 {
+		return hysteresis{trip: l.adaptTrip, clear: l.adaptClear}
+	}
+	return hysteresis{trip: trip, clear: clear}
+}
+
+// observe records a frame, and returns whether the action
+// should be in effect now.
+func (h *hysteresis) observe(overrun bool) bool {
+	if overrun {
+		h.bad++
+		h.good = 0
+		if !h.tripped && h.bad >= h.trip {
+			h.tripped = true
+		}
+	} else {
+		h.good++
+		h.bad = 0
+		if h.tripped && h.clear > 0 && h.good >= h.clear {
+			h.tripped = false
+		}
+	}
+	return h.tripped
+}
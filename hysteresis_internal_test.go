@@ -0,0 +1,30 @@
+package gloop
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHysteresis(t *testing.T) {
+	h := hysteresis{trip: 3, clear: 2}
+	feed := func(overruns ...bool) bool {
+		var tripped bool
+		for _, overrun := range overruns {
+			tripped = h.observe(overrun)
+		}
+		return tripped
+	}
+
+	// Spikes with healthy frames between them never trip it.
+	assert.False(t, feed(true, true, false, true, false, true, true))
+	// A third overrun in a row does.
+	assert.True(t, feed(true))
+	// One healthy frame isn't enough to back off.
+	assert.True(t, feed(false, true, false))
+	assert.False(t, feed(false))
+
+	// With no clear count, it stays tripped.
+	h = hysteresis{trip: 1}
+	assert.True(t, feed(true, false, false, false))
+}
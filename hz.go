@@ -0,0 +1,61 @@
+package gloop
+
+import (
+	"time"
+)
+
+// hzToLatency converts a frequency to the delay between calls.
+func hzToLatency(hz float64, name string) (time.Duration, error) {
+	if !(hz > 0) {
+		return 0, wrapLoopError(nil, TokenLoop, "%s can't be lte 0", name)
+	}
+	latency := time.Duration(float64(time.Second) / hz)
+	if latency <= 0 {
+		return 0, wrapLoopError(nil, TokenLoop, "%s is too high", name)
+	}
+	return latency, nil
+}
+
+// WithSimulationHz sets how many times a second Simulate is called.
+// It replaces the SimulationLatency passed to NewLoop,
+// and any earlier WithSimulationRate.
+func WithSimulationHz(hz float64) Option {
+	return func(l *Loop) error {
+		latency, err := hzToLatency(hz, "SimulationHz")
+		if err != nil {
+			return err
+		}
+		l.simRate = nil
+		l.SimulationLatency = latency
+		return nil
+	}
+}
+
+// WithRenderHz sets how many times a second Render is called.
+// It replaces the RenderLatency passed to NewLoop.
+func WithRenderHz(hz float64) Option {
+	return func(l *Loop) error {
+		latency, err := hzToLatency(hz, "RenderHz")
+		if err != nil {
+			return err
+		}
+		l.RenderLatency = latency
+		return nil
+	}
+}
+
+// SimulationHz is how many times a second Simulate is called.
+// It's safe to call while SetPreset is changing the rate.
+func (l *Loop) SimulationHz() float64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return float64(time.Second) / float64(l.SimulationLatency)
+}
+
+// RenderHz is how many times a second Render is called.
+// It's safe to call while SetPreset is changing the rate.
+func (l *Loop) RenderHz() float64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return float64(time.Second) / float64(l.RenderLatency)
+}
@@ -0,0 +1,56 @@
+package gloop_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/erinpentecost/gloop"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHz(t *testing.T) {
+	loop, err := gloop.NewLoop(nil, nil, time.Second, time.Second, gloop.WithSimulationHz(60), gloop.WithRenderHz(144))
+	assert.Nil(t, err)
+
+	assert.InDelta(t, gloop.Hz60Delay, loop.SimulationLatency, 1)
+	assert.InDelta(t, time.Second/144, loop.RenderLatency, 1)
+	assert.InDelta(t, 60, loop.SimulationHz(), 1e-3)
+	assert.InDelta(t, 144, loop.RenderHz(), 1e-3)
+}
+
+func TestHzValidation(t *testing.T) {
+	for _, hz := range []float64{0, -60, math.NaN(), math.Inf(1)} {
+		_, err := gloop.NewLoop(nil, nil, gloop.Hz60Delay, gloop.Hz60Delay, gloop.WithSimulationHz(hz))
+		assert.NotNil(t, err)
+		_, err = gloop.NewLoop(nil, nil, gloop.Hz60Delay, gloop.Hz60Delay, gloop.WithRenderHz(hz))
+		assert.NotNil(t, err)
+	}
+}
+
+func TestHzWhileSwitchingPresets(t *testing.T) {
+	loop, err := gloop.NewLoop(nil, nil, gloop.Hz60Delay, gloop.Hz60Delay,
+		gloop.WithRateLadder(map[string]gloop.RatePreset{
+			"low":  {SimHz: 30, RenderHz: 30},
+			"high": {SimHz: 120, RenderHz: 120},
+		}))
+	assert.Nil(t, err)
+	assert.Nil(t, loop.Start())
+
+	// Run with -race to check the getters against the switch.
+	deadline := time.Now().Add(200 * time.Millisecond)
+	for i := 0; time.Now().Before(deadline); i++ {
+		preset := "low"
+		if i%2 == 1 {
+			preset = "high"
+		}
+		assert.Nil(t, loop.SetPreset(preset))
+		time.Sleep(time.Millisecond)
+		for _, hz := range []float64{loop.SimulationHz(), loop.RenderHz()} {
+			assert.True(t, math.Abs(hz-30) < 1e-3 || math.Abs(hz-60) < 1e-3 || math.Abs(hz-120) < 1e-3, "hz: %f", hz)
+		}
+	}
+	loop.Stop(nil)
+	<-loop.Done()
+	assert.Nil(t, loop.Err())
+}
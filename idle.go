@@ -0,0 +1,43 @@
+package gloop
+
+import (
+	"time"
+)
+
+// WithIdleRender drops Render to idleHz while nothing is changing,
+// to save battery. dirty is called before each render frame, and should
+// report whether there is anything new to draw, such as whether
+// Simulate changed the world since the last frame. While it returns
+// false, frames are only drawn idleHz times a second. As soon as it
+// returns true, rendering goes back to its full rate.
+// WithHysteresis makes it take more frames in a row either way.
+// dirty is called on the goroutine that renders.
+func WithIdleRender(dirty func() bool, idleHz float64) Option {
+	return func(l *Loop) error {
+		if dirty == nil {
+			return wrapLoopError(nil, TokenLoop, "IdleRender dirty can't be nil")
+		}
+		every, err := hzToLatency(idleHz, "IdleRender")
+		if err != nil {
+			return err
+		}
+		l.idleDirty = dirty
+		l.idleEvery = every
+		return nil
+	}
+}
+
+// idleSkip is true if this render frame should be skipped
+// because nothing has changed since the last one was drawn.
+func (r *runner) idleSkip() bool {
+	l := r.l
+	if l.idleDirty == nil {
+		return false
+	}
+	now := time.Now()
+	if r.idle.observe(!l.idleDirty()) && now.Sub(r.lastDrawn) < l.idleEvery {
+		return true
+	}
+	r.lastDrawn = now
+	return false
+}
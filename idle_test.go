@@ -0,0 +1,77 @@
+package gloop_test
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/erinpentecost/gloop"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIdleRender(t *testing.T) {
+	const renderLatency = 5 * time.Millisecond
+	var dirty, renders int32
+	render := func(step time.Duration) error {
+		atomic.AddInt32(&renders, 1)
+		return nil
+	}
+	isDirty := func() bool {
+		return atomic.LoadInt32(&dirty) == 1
+	}
+	loop, err := gloop.NewLoop(render, nil, renderLatency, renderLatency,
+		gloop.WithIdleRender(isDirty, 10))
+	assert.Nil(t, err)
+	assert.Nil(t, loop.Start())
+
+	// Idle, so about 3 frames at 10Hz, rather than 60 at full rate.
+	time.Sleep(300 * time.Millisecond)
+	idle := atomic.SwapInt32(&renders, 0)
+
+	atomic.StoreInt32(&dirty, 1)
+	time.Sleep(300 * time.Millisecond)
+	active := atomic.LoadInt32(&renders)
+
+	loop.Stop(nil)
+	<-loop.Done()
+	assert.Nil(t, loop.Err())
+
+	assert.True(t, idle >= 1)
+	assert.True(t, idle <= 5, "idle renders: %d", idle)
+	assert.True(t, active >= 20, "active renders: %d", active)
+}
+
+func TestIdleRenderHysteresis(t *testing.T) {
+	const renderLatency = 5 * time.Millisecond
+	var renders int32
+	render := func(step time.Duration) error {
+		atomic.AddInt32(&renders, 1)
+		return nil
+	}
+	isDirty := func() bool {
+		return false
+	}
+	loop, err := gloop.NewLoop(render, nil, renderLatency, renderLatency,
+		gloop.WithIdleRender(isDirty, 10), gloop.WithHysteresis(20, 1))
+	assert.Nil(t, err)
+	assert.Nil(t, loop.Start())
+
+	// The first 20 quiet frames are still drawn at the full rate.
+	time.Sleep(300 * time.Millisecond)
+	loop.Stop(nil)
+	<-loop.Done()
+	assert.Nil(t, loop.Err())
+
+	drawn := atomic.LoadInt32(&renders)
+	assert.True(t, drawn >= 15, "renders: %d", drawn)
+	assert.True(t, drawn <= 30, "renders: %d", drawn)
+}
+
+func TestIdleRenderOptions(t *testing.T) {
+	_, err := gloop.NewLoop(nil, nil, gloop.Hz60Delay, gloop.Hz60Delay,
+		gloop.WithIdleRender(nil, 10))
+	assert.NotNil(t, err)
+	_, err = gloop.NewLoop(nil, nil, gloop.Hz60Delay, gloop.Hz60Delay,
+		gloop.WithIdleRender(func() bool { return false }, 0))
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,36 @@
+package gloop
+
+import (
+	"time"
+)
+
+// WithInitialStep sets the time that the first simulate frame and the
+// first render frame take to have passed, instead of measuring it.
+// Without it, the first simulate frame sees almost no time pass and
+// runs no steps, and the first render step depends on the skip policy.
+// Set it to SimulationLatency to have Simulate run as soon as the loop
+// starts, and to give physics that divides by the render step a sane
+// first value. It has no effect in step mode.
+func WithInitialStep(d time.Duration) Option {
+	return func(l *Loop) error {
+		if d <= 0 {
+			return wrapLoopError(nil, TokenLoop, "InitialStep can't be lte 0")
+		}
+		l.initialStep = d
+		return nil
+	}
+}
+
+// firstFrameTime returns the initial step in place of frameTime
+// the first time it is called for a frame kind, if one was set.
+// seen tracks whether that kind of frame has run before.
+func (r *runner) firstFrameTime(frameTime time.Duration, seen *bool) time.Duration {
+	if *seen {
+		return frameTime
+	}
+	*seen = true
+	if r.l.initialStep > 0 {
+		return r.l.initialStep
+	}
+	return frameTime
+}
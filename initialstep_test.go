@@ -0,0 +1,49 @@
+package gloop_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/erinpentecost/gloop"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInitialStep(t *testing.T) {
+	const initial = 3 * time.Millisecond
+	var firstSim time.Time
+	var renderSteps []time.Duration
+	loop, err := gloop.NewLoop(nil, nil, gloop.Hz60Delay, gloop.Hz60Delay,
+		gloop.WithInitialStep(gloop.Hz60Delay+initial),
+		gloop.WithRenderSkipPolicy(gloop.RenderDropToLatest))
+	assert.Nil(t, err)
+	loop.Simulate = func(step time.Duration) error {
+		assert.Equal(t, gloop.Hz60Delay, step)
+		if firstSim.IsZero() {
+			firstSim = time.Now()
+		}
+		return nil
+	}
+	loop.Render = func(step time.Duration) error {
+		renderSteps = append(renderSteps, step)
+		if len(renderSteps) == 3 {
+			loop.Stop(nil)
+		}
+		return nil
+	}
+
+	start := time.Now()
+	assert.Nil(t, loop.Start())
+	<-loop.Done()
+	assert.Nil(t, loop.Err())
+
+	// The first simulate frame already has a whole step built up.
+	assert.True(t, firstSim.Sub(start) < gloop.Hz60Delay/2, "first Simulate after %s", firstSim.Sub(start))
+	assert.Equal(t, gloop.Hz60Delay+initial, renderSteps[0])
+	// Later frames are measured as usual.
+	assert.NotEqual(t, gloop.Hz60Delay+initial, renderSteps[1])
+}
+
+func TestInitialStepValidation(t *testing.T) {
+	_, err := gloop.NewLoop(nil, nil, gloop.Hz60Delay, gloop.Hz60Delay, gloop.WithInitialStep(0))
+	assert.NotNil(t, err)
+}
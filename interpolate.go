@@ -0,0 +1,121 @@
+package gloop
+
+import (
+	"time"
+)
+
+// WithRenderEasing applies fn to the interpolation alpha
+// before it is made available to Render through Alpha().
+// This is useful for grid games, where moves between whole
+// cells look better eased than linear.
+// The default easing is the identity function.
+func WithRenderEasing(fn func(alpha float64) float64) Option {
+	return func(l *Loop) error {
+		if fn == nil {
+			return wrapLoopError(nil, TokenLoop, "Render easing can't be nil")
+		}
+		l.renderEasing = fn
+		return nil
+	}
+}
+
+// Alpha is how far the current render falls between the last
+// simulation step and the next one, from 0 to 1.
+// Call it from inside Render to interpolate between the previous
+// and current simulation state.
+func (l *Loop) Alpha() float64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.alpha
+}
+
+// setAlpha records the alpha for the upcoming render, given how much
+// time has built up since the last simulation step.
+// It returns the eased alpha.
+func (l *Loop) setAlpha(pending, step time.Duration) float64 {
+	alpha := float64(pending) / float64(step)
+	if alpha < 0 {
+		alpha = 0
+	} else if alpha > 1 {
+		alpha = 1
+	}
+	if l.renderEasing != nil {
+		alpha = l.renderEasing(alpha)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.alpha = alpha
+	return alpha
+}
+
+// Settled is true during the first render after the simulation ran
+// several steps in one frame to catch up.
+// Renderers that do expensive work can wait for this frame,
+// rather than drawing state that is still changing quickly.
+// Call it from inside Render.
+func (l *Loop) Settled() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.settled
+}
+
+func (l *Loop) setSettled(settled bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.settled = settled
+}
+
+// interpolationState is what InterpolationState returns.
+type interpolationState struct {
+	tick        uint64
+	tickTime    time.Time
+	accumulator time.Duration
+}
+
+// InterpolationState returns how many Simulate steps have run, when the
+// last one finished, and how much time has built up towards the next one.
+// All three are taken together at the end of the same simulate frame,
+// so a networked client can use them to reconcile with server snapshots.
+// Before the first simulate frame, all three are zero.
+func (l *Loop) InterpolationState() (lastTick uint64, lastTickTime time.Time, accumulator time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.interp.tick, l.interp.tickTime, l.interp.accumulator
+}
+
+// publishInterpolation records the state returned by InterpolationState.
+// Call it once a simulate frame is over.
+func (r *runner) publishInterpolation() {
+	l := r.l
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.interp = interpolationState{
+		tick:        l.stats.SimTicks,
+		tickTime:    r.lastSimStep,
+		accumulator: r.simAccumulator,
+	}
+}
+
+// simClock is a snapshot of the simulation time that has built up,
+// for working out alpha.
+type simClock struct {
+	// at is when the last simulate frame ran.
+	at time.Time
+	// accumulated is the leftover simulation time as of at.
+	accumulated time.Duration
+	// scale is the time scale as of at.
+	scale float64
+}
+
+// pending is how much simulation time has built up as of now.
+func (c simClock) pending(now time.Time) time.Duration {
+	return c.accumulated + time.Duration(float64(now.Sub(c.at))*c.scale)
+}
+
+// publishSimClock makes the pending simulation time available to render.
+func (r *runner) publishSimClock(scale float64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.simClock = simClock{at: r.previousSim, accumulated: r.simAccumulator, scale: scale}
+}
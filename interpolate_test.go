@@ -0,0 +1,140 @@
+package gloop_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/erinpentecost/gloop"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderEasing(t *testing.T) {
+	var eased, raw float64
+	simulate := func(step time.Duration) error {
+		return nil
+	}
+	easing := func(alpha float64) float64 {
+		raw = alpha
+		return 0.42
+	}
+	loop, err := gloop.NewLoop(nil, simulate, gloop.Hz60Delay, gloop.Hz60Delay, gloop.WithRenderEasing(easing))
+	assert.Nil(t, err)
+	assert.NotNil(t, loop)
+	loop.Render = func(step time.Duration) error {
+		eased = loop.Alpha()
+		loop.Stop(nil)
+		return nil
+	}
+	err = loop.Start()
+	assert.Nil(t, err)
+	<-loop.Done()
+	assert.Nil(t, loop.Err())
+
+	assert.Equal(t, 0.42, eased)
+	assert.True(t, raw >= 0 && raw <= 1)
+}
+
+func TestRenderEasingNil(t *testing.T) {
+	loop, err := gloop.NewLoop(nil, nil, gloop.Hz60Delay, gloop.Hz60Delay, gloop.WithRenderEasing(nil))
+	assert.NotNil(t, err)
+	assert.Nil(t, loop)
+}
+
+func TestSettled(t *testing.T) {
+	var order []string
+	loop, err := gloop.NewLoop(nil, nil, gloop.Hz60Delay, gloop.Hz60Delay)
+	assert.Nil(t, err)
+	assert.NotNil(t, loop)
+	loop.Simulate = func(step time.Duration) error {
+		order = append(order, "simulate")
+		if len(order) == 1 {
+			// Stall so the next frame has to catch up.
+			time.Sleep(5 * gloop.Hz60Delay)
+		}
+		return nil
+	}
+	renders := 0
+	loop.Render = func(step time.Duration) error {
+		renders++
+		if loop.Settled() {
+			order = append(order, "settled")
+		} else {
+			order = append(order, "render")
+		}
+		if renders == 10 {
+			loop.Stop(nil)
+		}
+		return nil
+	}
+	err = loop.Start()
+	assert.Nil(t, err)
+	<-loop.Done()
+	assert.Nil(t, loop.Err())
+
+	settled := 0
+	steps := 0
+	for _, name := range order {
+		switch name {
+		case "simulate":
+			steps++
+		case "settled":
+			settled++
+			assert.True(t, steps > 1)
+			steps = 0
+		case "render":
+			steps = 0
+		}
+	}
+	assert.True(t, settled >= 1)
+}
+
+func TestInterpolationState(t *testing.T) {
+	loop, err := gloop.NewLoop(nil, nil, gloop.Hz60Delay, gloop.Hz60Delay/3)
+	assert.Nil(t, err)
+	assert.NotNil(t, loop)
+
+	var calls uint64
+	loop.Simulate = func(step time.Duration) error {
+		calls++
+		return nil
+	}
+	renders := 0
+	loop.Render = func(step time.Duration) error {
+		// Render runs between simulate frames, so the snapshot is current.
+		tick, tickTime, accumulator := loop.InterpolationState()
+		assert.Equal(t, calls, tick)
+		if tick > 0 {
+			assert.False(t, tickTime.IsZero())
+		}
+		assert.True(t, accumulator >= 0)
+		assert.True(t, accumulator < loop.SimulationLatency)
+
+		renders++
+		if renders == 10 {
+			loop.Stop(nil)
+		}
+		return nil
+	}
+	err = loop.Start()
+	assert.Nil(t, err)
+	<-loop.Done()
+	assert.Nil(t, loop.Err())
+	assert.True(t, calls > 0)
+}
+
+func TestInterpolationStateStepMode(t *testing.T) {
+	loop, err := gloop.NewLoop(nil, nil, gloop.Hz60Delay, gloop.Hz60Delay)
+	assert.Nil(t, err)
+
+	tick, tickTime, accumulator := loop.InterpolationState()
+	assert.Zero(t, tick)
+	assert.True(t, tickTime.IsZero())
+	assert.Zero(t, accumulator)
+
+	before := time.Now()
+	assert.Nil(t, loop.StepN(7))
+	tick, tickTime, accumulator = loop.InterpolationState()
+	assert.Equal(t, uint64(7), tick)
+	assert.False(t, tickTime.Before(before))
+	assert.Zero(t, accumulator)
+}
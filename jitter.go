@@ -0,0 +1,58 @@
+package gloop
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// WithJitterInjection adds a random amount, between -maxJitter and
+// +maxJitter, to the time each simulate and render frame measures since
+// the last one, to check that a game copes with uneven frame times.
+// The amounts come from a generator seeded with seed, so a run that
+// turns up a problem can be repeated. Only frame times are changed, not
+// the real schedule, and a frame time never goes below 0.
+// This is for tests and development, not for release builds.
+func WithJitterInjection(seed int64, maxJitter time.Duration) Option {
+	return func(l *Loop) error {
+		if maxJitter <= 0 {
+			return wrapLoopError(nil, TokenLoop, "Jitter can't be lte 0")
+		}
+		l.jitterSeed = seed
+		l.jitterMax = maxJitter
+		return nil
+	}
+}
+
+// jitterSource perturbs frame times. A nil jitterSource leaves them be.
+// It has a lock because a concurrent render goroutine shares it.
+type jitterSource struct {
+	mu    sync.Mutex
+	rng   *rand.Rand
+	limit time.Duration
+}
+
+// newJitter is the loop's jitter source, or nil if it has none.
+func (l *Loop) newJitter() *jitterSource {
+	if l.jitterMax <= 0 {
+		return nil
+	}
+	return &jitterSource{
+		rng:   rand.New(rand.NewSource(l.jitterSeed)),
+		limit: l.jitterMax,
+	}
+}
+
+// perturb returns d moved by the next random amount.
+func (j *jitterSource) perturb(d time.Duration) time.Duration {
+	if j == nil {
+		return d
+	}
+	j.mu.Lock()
+	offset := time.Duration(j.rng.Int63n(2*int64(j.limit)+1)) - j.limit
+	j.mu.Unlock()
+	if d += offset; d < 0 {
+		return 0
+	}
+	return d
+}
@@ -0,0 +1,45 @@
+package gloop
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJitterSeeded(t *testing.T) {
+	sequence := func(seed int64) []time.Duration {
+		l, err := NewLoop(nil, nil, time.Millisecond, time.Millisecond,
+			WithJitterInjection(seed, 4*time.Millisecond))
+		assert.Nil(t, err)
+		jitter := l.newJitter()
+		var out []time.Duration
+		for i := 0; i < 100; i++ {
+			out = append(out, jitter.perturb(10*time.Millisecond))
+		}
+		return out
+	}
+
+	first := sequence(7)
+	assert.Equal(t, first, sequence(7))
+	assert.NotEqual(t, first, sequence(8))
+	varied := false
+	for _, d := range first {
+		assert.True(t, d >= 6*time.Millisecond && d <= 14*time.Millisecond)
+		varied = varied || d != first[0]
+	}
+	assert.True(t, varied)
+
+	// Frame times don't go negative.
+	l, err := NewLoop(nil, nil, time.Millisecond, time.Millisecond,
+		WithJitterInjection(1, time.Second))
+	assert.Nil(t, err)
+	jitter := l.newJitter()
+	for i := 0; i < 100; i++ {
+		assert.True(t, jitter.perturb(0) >= 0)
+	}
+
+	// Without the option, frame times are left alone.
+	var none *jitterSource
+	assert.Equal(t, time.Millisecond, none.perturb(time.Millisecond))
+}
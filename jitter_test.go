@@ -0,0 +1,40 @@
+package gloop_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/erinpentecost/gloop"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJitterInjection(t *testing.T) {
+	const latency = 10 * time.Millisecond
+	var steps []time.Duration
+	loop, err := gloop.NewLoop(nil, nil, latency, latency,
+		gloop.WithJitterInjection(42, 3*latency))
+	assert.Nil(t, err)
+	loop.Render = func(step time.Duration) error {
+		steps = append(steps, step)
+		if len(steps) == 20 {
+			loop.Stop(nil)
+		}
+		return nil
+	}
+	assert.Nil(t, loop.Start())
+	<-loop.Done()
+	assert.Nil(t, loop.Err())
+
+	// Render steps are usually within a millisecond or two of latency,
+	// but jitter spreads them far wider.
+	spread := 0
+	for _, step := range steps {
+		if step < latency/2 || step > latency*3/2 {
+			spread++
+		}
+	}
+	assert.True(t, spread >= 5, "steps %v", steps)
+
+	_, err = gloop.NewLoop(nil, nil, latency, latency, gloop.WithJitterInjection(42, 0))
+	assert.NotNil(t, err)
+}
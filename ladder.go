@@ -0,0 +1,103 @@
+package gloop
+
+import (
+	"time"
+)
+
+// RatePreset is a pair of rates for WithRateLadder, in Hz.
+type RatePreset struct {
+	SimHz    float64
+	RenderHz float64
+}
+
+// ratePreset is a RatePreset as latencies.
+type ratePreset struct {
+	name   string
+	sim    time.Duration
+	render time.Duration
+}
+
+// WithRateLadder defines named quality presets, like "low" and "high",
+// that each set the simulation and render rates together. Switch
+// between them with SetPreset. This can't be combined with
+// WithConcurrentRender.
+func WithRateLadder(presets map[string]RatePreset) Option {
+	return func(l *Loop) error {
+		if len(presets) == 0 {
+			return wrapLoopError(nil, TokenLoop, "RateLadder needs at least one preset")
+		}
+		l.presets = make(map[string]ratePreset, len(presets))
+		for name, preset := range presets {
+			sim, err := hzToLatency(preset.SimHz, "SimHz of preset "+name)
+			if err != nil {
+				return err
+			}
+			render, err := hzToLatency(preset.RenderHz, "RenderHz of preset "+name)
+			if err != nil {
+				return err
+			}
+			l.presets[name] = ratePreset{name: name, sim: sim, render: render}
+		}
+		return nil
+	}
+}
+
+// SetPreset switches to the WithRateLadder preset called name,
+// replacing SimulationLatency and RenderLatency, and any rate set by
+// WithSimulationRate. Before the loop is started, the switch is made
+// right away. While it runs, both rates change together between
+// frames, so no frame sees one without the other. Leftover simulation
+// time carries over.
+// Only read SimulationLatency and RenderLatency from inside Simulate
+// or Render while the loop runs, since they may change under you
+// anywhere else; SimulationHz and RenderHz are safe to call from any
+// goroutine. It returns an error if there's no such preset.
+func (l *Loop) SetPreset(name string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	preset, ok := l.presets[name]
+	if !ok {
+		return wrapLoopError(nil, TokenLoop, "There is no rate preset called %q", name)
+	}
+	if l.curState == stateInit {
+		l.usePreset(preset)
+		return nil
+	}
+	l.presetDue = &preset
+	return nil
+}
+
+// Preset is the name of the preset last switched to, or "" if
+// SetPreset hasn't been called. It changes once the switch is made.
+func (l *Loop) Preset() string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.preset
+}
+
+// usePreset sets the latencies from preset. l.mu must be held.
+func (l *Loop) usePreset(preset ratePreset) {
+	l.preset = preset.name
+	l.simRate = nil
+	l.SimulationLatency = preset.sim
+	l.RenderLatency = preset.render
+}
+
+// applyPreset makes any switch asked for by SetPreset,
+// and reschedules both timers for the new rates.
+func (r *runner) applyPreset() {
+	l := r.l
+	l.mu.Lock()
+	preset := l.presetDue
+	l.presetDue = nil
+	if preset != nil {
+		l.usePreset(*preset)
+	}
+	l.mu.Unlock()
+	if preset == nil {
+		return
+	}
+	r.applyRenderLimit()
+	// Simulate right away, to work out when the next step is due.
+	r.resetSimTimer(0)
+}
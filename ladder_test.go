@@ -0,0 +1,98 @@
+package gloop_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/erinpentecost/gloop"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLadder(t *testing.T) {
+	presets := map[string]gloop.RatePreset{
+		"low":  {SimHz: 50, RenderHz: 25},
+		"high": {SimHz: 100, RenderHz: 50},
+	}
+	var mu sync.Mutex
+	var simSteps []time.Duration
+	var renderTimes []time.Time
+	simulate := func(step time.Duration) error {
+		mu.Lock()
+		defer mu.Unlock()
+		simSteps = append(simSteps, step)
+		return nil
+	}
+	render := func(step time.Duration) error {
+		mu.Lock()
+		defer mu.Unlock()
+		renderTimes = append(renderTimes, time.Now())
+		return nil
+	}
+	loop, err := gloop.NewLoop(render, simulate, gloop.Hz60Delay, gloop.Hz60Delay,
+		gloop.WithRateLadder(presets))
+	assert.Nil(t, err)
+	assert.NotNil(t, loop.SetPreset("ultra"))
+	assert.Nil(t, loop.SetPreset("low"))
+	assert.Equal(t, "low", loop.Preset())
+	assert.Equal(t, 20*time.Millisecond, loop.SimulationLatency)
+	assert.Equal(t, 40*time.Millisecond, loop.RenderLatency)
+
+	// window returns the sim steps and the mean time between
+	// renders since the last call.
+	window := func() ([]time.Duration, time.Duration) {
+		mu.Lock()
+		defer mu.Unlock()
+		steps, times := simSteps, renderTimes
+		simSteps, renderTimes = nil, nil
+		if len(times) < 2 {
+			return steps, 0
+		}
+		return steps, times[len(times)-1].Sub(times[0]) / time.Duration(len(times)-1)
+	}
+
+	assert.Nil(t, loop.Start())
+	time.Sleep(400 * time.Millisecond)
+	lowSteps, lowRender := window()
+
+	assert.Nil(t, loop.SetPreset("high"))
+	// Give the switch a frame to land.
+	time.Sleep(50 * time.Millisecond)
+	window()
+	time.Sleep(400 * time.Millisecond)
+	highSteps, highRender := window()
+	assert.Equal(t, "high", loop.Preset())
+
+	loop.Stop(nil)
+	<-loop.Done()
+	assert.Nil(t, loop.Err())
+
+	assert.NotEmpty(t, lowSteps)
+	for _, step := range lowSteps {
+		assert.Equal(t, 20*time.Millisecond, step)
+	}
+	assert.NotEmpty(t, highSteps)
+	for _, step := range highSteps {
+		assert.Equal(t, 10*time.Millisecond, step)
+	}
+	assert.True(t, lowRender > 30*time.Millisecond && lowRender < 50*time.Millisecond, "low render every %s", lowRender)
+	assert.True(t, highRender > 15*time.Millisecond && highRender < 25*time.Millisecond, "high render every %s", highRender)
+}
+
+func TestRateLadderOptions(t *testing.T) {
+	_, err := gloop.NewLoop(nil, nil, gloop.Hz60Delay, gloop.Hz60Delay,
+		gloop.WithRateLadder(nil))
+	assert.NotNil(t, err)
+	_, err = gloop.NewLoop(nil, nil, gloop.Hz60Delay, gloop.Hz60Delay,
+		gloop.WithRateLadder(map[string]gloop.RatePreset{"bad": {SimHz: 0, RenderHz: 60}}))
+	assert.NotNil(t, err)
+	_, err = gloop.NewLoop(nil, nil, gloop.Hz60Delay, gloop.Hz60Delay,
+		gloop.WithRateLadder(map[string]gloop.RatePreset{"ok": {SimHz: 60, RenderHz: 60}}),
+		gloop.WithConcurrentRender())
+	assert.NotNil(t, err)
+
+	loop, err := gloop.NewLoop(nil, nil, gloop.Hz60Delay, gloop.Hz60Delay)
+	assert.Nil(t, err)
+	assert.NotNil(t, loop.SetPreset("ok"))
+	assert.Equal(t, "", loop.Preset())
+}
@@ -8,4 +8,36 @@ import (
 type LatencySample struct {
 	RenderLatency   time.Duration
 	SimulateLatency time.Duration
+	// Utilization is the fraction of wall time since the last sample
+	// that was spent inside Render and Simulate.
+	// Near 0 there is plenty of headroom; near 1 frames are about to drop.
+	Utilization float64
+	// RenderServiceEWMA and SimulateServiceEWMA are moving averages of
+	// how long each callback takes. They are only set with WithEWMA.
+	RenderServiceEWMA   time.Duration
+	SimulateServiceEWMA time.Duration
+	// Dropped is how many heartbeat samples were thrown away since the
+	// last one that made it onto the Heartbeat channel, because no one
+	// was listening or its buffer was full.
+	Dropped uint64
+}
+
+// RenderLatencyMs is RenderLatency in milliseconds.
+func (s LatencySample) RenderLatencyMs() float64 {
+	return float64(s.RenderLatency) / float64(time.Millisecond)
+}
+
+// RenderLatencyUs is RenderLatency in microseconds.
+func (s LatencySample) RenderLatencyUs() float64 {
+	return float64(s.RenderLatency) / float64(time.Microsecond)
+}
+
+// SimulateLatencyMs is SimulateLatency in milliseconds.
+func (s LatencySample) SimulateLatencyMs() float64 {
+	return float64(s.SimulateLatency) / float64(time.Millisecond)
+}
+
+// SimulateLatencyUs is SimulateLatency in microseconds.
+func (s LatencySample) SimulateLatencyUs() float64 {
+	return float64(s.SimulateLatency) / float64(time.Microsecond)
 }
@@ -0,0 +1,20 @@
+package gloop_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/erinpentecost/gloop"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLatencySampleUnits(t *testing.T) {
+	sample := gloop.LatencySample{
+		RenderLatency:   1500 * time.Microsecond,
+		SimulateLatency: 250 * time.Nanosecond,
+	}
+	assert.Equal(t, 1.5, sample.RenderLatencyMs())
+	assert.Equal(t, 1500.0, sample.RenderLatencyUs())
+	assert.Equal(t, 0.00025, sample.SimulateLatencyMs())
+	assert.Equal(t, 0.25, sample.SimulateLatencyUs())
+}
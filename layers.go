@@ -0,0 +1,68 @@
+package gloop
+
+import (
+	"time"
+)
+
+// LayerID identifies a render layer.
+type LayerID uint64
+
+type renderLayer struct {
+	id LayerID
+	fn LoopFn
+}
+
+// AddRenderLayer adds fn as a render layer.
+// Layers are called in the order they were added, after Render,
+// on every render tick. They receive the same step as Render and
+// can read Alpha() the same way.
+// If a layer returns an error, the loop stops and the layer's id
+// is recorded under "layer" in the LoopError's Misc.
+// This is safe to call at any time, including from inside a callback.
+func (l *Loop) AddRenderLayer(fn LoopFn) LayerID {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.nextLayer++
+	// Copy on write so the loop can iterate without holding the lock.
+	layers := make([]renderLayer, len(l.layers), len(l.layers)+1)
+	copy(layers, l.layers)
+	l.layers = append(layers, renderLayer{id: l.nextLayer, fn: fn})
+	return l.nextLayer
+}
+
+// RemoveRenderLayer removes a render layer.
+// The layer will not be called on any render tick after the current one.
+// Removing a layer that doesn't exist does nothing.
+func (l *Loop) RemoveRenderLayer(id LayerID) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	layers := make([]renderLayer, 0, len(l.layers))
+	for _, layer := range l.layers {
+		if layer.id != id {
+			layers = append(layers, layer)
+		}
+	}
+	l.layers = layers
+}
+
+// renderLayers calls each render layer in order.
+// Any returned error is already wrapped.
+func (r *runner) renderLayers(step time.Duration, curTime time.Time) error {
+	l := r.l
+	l.mu.Lock()
+	layers := l.layers
+	l.mu.Unlock()
+
+	for _, layer := range layers {
+		if er := r.invoke(renderLabels, renderRegion, step, func() error { return layer.fn(step) }); er != nil {
+			wrapped := wrapLoopError(er, TokenRender, "Error returned by render layer %d (%s)", layer.id, step.String())
+			wrapped.Misc["curTime"] = curTime
+			wrapped.Misc["layer"] = layer.id
+			l.reportError(wrapped)
+			return wrapped
+		}
+	}
+	return nil
+}
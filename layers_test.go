@@ -0,0 +1,67 @@
+package gloop_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/erinpentecost/gloop"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderLayers(t *testing.T) {
+	simulate := func(step time.Duration) error {
+		return nil
+	}
+	loop, err := gloop.NewLoop(nil, simulate, gloop.Hz60Delay, gloop.Hz60Delay)
+	assert.Nil(t, err)
+	assert.NotNil(t, loop)
+
+	worldCalls := 0
+	hudCalls := 0
+	var hud gloop.LayerID
+	loop.AddRenderLayer(func(step time.Duration) error {
+		worldCalls++
+		if worldCalls == 5 {
+			loop.RemoveRenderLayer(hud)
+		}
+		if worldCalls == 10 {
+			loop.Stop(nil)
+		}
+		return nil
+	})
+	hud = loop.AddRenderLayer(func(step time.Duration) error {
+		hudCalls++
+		return nil
+	})
+
+	err = loop.Start()
+	assert.Nil(t, err)
+	<-loop.Done()
+	assert.Nil(t, loop.Err())
+
+	assert.Equal(t, 10, worldCalls)
+	// The hud was removed partway through the fifth tick,
+	// so it still ran for that one.
+	assert.Equal(t, 5, hudCalls)
+}
+
+func TestRenderLayerError(t *testing.T) {
+	simulate := func(step time.Duration) error {
+		return nil
+	}
+	loop, err := gloop.NewLoop(nil, simulate, gloop.Hz60Delay, gloop.Hz60Delay)
+	assert.Nil(t, err)
+	assert.NotNil(t, loop)
+	id := loop.AddRenderLayer(func(step time.Duration) error {
+		return fmt.Errorf("Intentional error")
+	})
+	err = loop.Start()
+	assert.Nil(t, err)
+	<-loop.Done()
+
+	loopErr, ok := loop.Err().(gloop.LoopError)
+	assert.True(t, ok)
+	assert.Equal(t, gloop.TokenRender, loopErr.ErrorSource)
+	assert.Equal(t, id, loopErr.Misc["layer"])
+}
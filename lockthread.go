@@ -0,0 +1,33 @@
+package gloop
+
+import (
+	"runtime"
+)
+
+// WithLockOSThread runs the loop goroutine on an OS thread of its own,
+// and asks the OS to schedule that thread ahead of others, so a busy
+// machine is less likely to make frames late. With WithConcurrentRender,
+// the render goroutine gets the same treatment.
+// Raising the priority is best effort: it is done on Linux and Windows,
+// and is quietly skipped where the OS doesn't allow it.
+func WithLockOSThread() Option {
+	return func(l *Loop) error {
+		l.lockOSThread = true
+		return nil
+	}
+}
+
+// lockThread locks the calling goroutine to its thread and raises the
+// thread's priority, if WithLockOSThread was set. Defer the returned
+// func to undo both.
+func (l *Loop) lockThread() func() {
+	if !l.lockOSThread {
+		return func() {}
+	}
+	runtime.LockOSThread()
+	restore := platformRaisePriority()
+	return func() {
+		restore()
+		runtime.UnlockOSThread()
+	}
+}
@@ -0,0 +1,57 @@
+//go:build linux
+// +build linux
+
+package gloop_test
+
+import (
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/erinpentecost/gloop"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLockOSThread(t *testing.T) {
+	threads := map[int]bool{}
+	var mu sync.Mutex
+	record := func(step time.Duration) error {
+		mu.Lock()
+		defer mu.Unlock()
+		threads[syscall.Gettid()] = true
+		return nil
+	}
+	loop, err := gloop.NewLoop(record, record, 2*time.Millisecond, time.Millisecond, gloop.WithLockOSThread())
+	assert.Nil(t, err)
+
+	// Keep other goroutines busy, so an unlocked goroutine would
+	// likely wake up on a different thread.
+	stop := make(chan struct{})
+	var churn sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		churn.Add(1)
+		go func() {
+			defer churn.Done()
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+					time.Sleep(50 * time.Microsecond)
+				}
+			}
+		}()
+	}
+
+	assert.Nil(t, loop.Start())
+	time.Sleep(200 * time.Millisecond)
+	loop.Stop(nil)
+	<-loop.Done()
+	close(stop)
+	churn.Wait()
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Len(t, threads, 1)
+}
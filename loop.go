@@ -21,17 +21,27 @@ const (
 // elapsed since the last call.
 type LoopFn func(step time.Duration) error
 
+// DeadlineLoopFn is a render function that is also told when
+// the next render is due, so it can drop detail to make the frame.
+type DeadlineLoopFn func(step time.Duration, deadline time.Time) error
+
 // Loop is a game loop.
 type Loop struct {
 	// Render is an elastic-step function.
 	Render LoopFn
+	// RenderWithDeadline is called instead of Render if it is set.
+	RenderWithDeadline DeadlineLoopFn
 	// Simulate is a fixed-step function.
 	Simulate LoopFn
 	// RenderRate controls how often Render will be called.
 	// This is the time delay between calls.
+	// SetPreset can change it while the loop runs, so from then on only
+	// read it inside Simulate or Render. Use RenderHz anywhere else.
 	RenderLatency time.Duration
 	// SimulationRate controls how often Simulate will be called.
 	// This is the time delay between calls.
+	// SetPreset can change it while the loop runs, so from then on only
+	// read it inside Simulate or Render. Use SimulationHz anywhere else.
 	SimulationLatency time.Duration
 	mu                sync.Mutex
 	runOnce           sync.Once
@@ -40,10 +50,120 @@ type Loop struct {
 	err               error
 	heartbeat         chan LatencySample
 	curState          state
+	started           bool
+	exited            chan interface{}
+	heartbeatOnce     sync.Once
+
+	// Configured by options.
+	renderEasing        func(alpha float64) float64
+	eventFilter         uint
+	spikeThreshold      float64
+	maxSimSteps         int
+	noCatchUp           bool
+	renderFirst         bool
+	stepRender          bool
+	keepTimerResolution bool
+	renderSkip          RenderSkipPolicy
+	maxRenderStep       time.Duration
+	beforeSim           func(tick uint64)
+	afterSim            func(tick uint64)
+	stopErrorMapper     func(LoopError) error
+	renderDriven        bool
+	concurrentRender    bool
+	raceGuard           bool
+	simRate             *rationalRate
+	renderStrikes       int
+	ewmaAlpha           float64
+	maxObservers        int
+	frameReport         func(FrameReport)
+	initialStep         time.Duration
+	warmupBeats         int
+	externalTick        <-chan time.Time
+	adaptTrip           int
+	adaptClear          int
+	allocEvery          int
+	lockOSThread        bool
+	stallWindow         time.Duration
+	stallStop           bool
+	maxPhysicsStep      time.Duration
+	runOnCaller         bool
+	idleDirty           func() bool
+	idleEvery           time.Duration
+	panicHandler        func(recovered interface{}, source TokenSource)
+	repanic             bool
+	simPhase            time.Duration
+	healthYellow        float64
+	healthRed           float64
+	jitterSeed          int64
+	jitterMax           time.Duration
+	batchSimulate       BatchFn
+	traceLimit          int
+	variableSim         bool
+	presets             map[string]ratePreset
+
+	// Published by the loop goroutine.
+	alpha      float64
+	settled    bool
+	lastBeat   LatencySample
+	hasBeat    bool
+	nextSim    time.Time
+	nextRender time.Time
+	events     chan Event
+	eventsOnce sync.Once
+	spikes     chan SpikeEvent
+	spikesOnce sync.Once
+	stalls     chan StallEvent
+	stallsOnce sync.Once
+	lastTick   time.Time
+	hints      chan PresentHint
+	hintsOnce  sync.Once
+	ready      chan struct{}
+	readyOnce  sync.Once
+	stats      LoopStats
+	interp     interpolationState
+	frameTimes frameWindow
+	health     healthWindow
+	trace      traceBuffer
+	// Lifetime service times for LifetimeQuantile.
+	renderSketch quantileSketch
+	simSketch    quantileSketch
+
+	// frameMu is held while a render frame or commits run.
+	frameMu sync.Mutex
+	// simulating and rendering are the goroutines running each callback,
+	// or 0 if it isn't running, for the race guard.
+	simulating uint64
+	rendering  uint64
+
+	simPaused bool
+	pauseRefs int
+	suspended bool
+	frozen    bool
+	resumes   uint64
+	renderOff bool
+	timeScale float64
+	scaleRamp *timeScaleRamp
+	forced    chan chan error
+	advance   chan chan error
+	commits   []func()
+	layers    []renderLayer
+	observers []observer
+	onError   []func(LoopError)
+	nextObs   uint64
+	nextLayer LayerID
+	stepper   *runner
+	goids     goroutineSet
+	renderCap time.Duration
+	setAcc    *time.Duration
+	budgets   [2]callBudget
+	baseline  uint64
+	preset    string
+	presetDue *ratePreset
 }
 
 // NewLoop creates a new game loop.
-func NewLoop(Render, Simulate LoopFn, RenderLatency, SimulationLatency time.Duration) (*Loop, error) {
+// Options are applied in order after the required arguments.
+func NewLoop(Render, Simulate LoopFn, RenderLatency, SimulationLatency time.Duration, opts ...Option) (*Loop, error) {
 	// Input validation.
 	if RenderLatency <= 0 {
 		return nil, wrapLoopError(nil, TokenLoop, "RenderRate can't be lte 0")
@@ -53,7 +173,7 @@ func NewLoop(Render, Simulate LoopFn, RenderLatency, SimulationLatency time.Dura
 	}
 
 	// Init loop.
-	return &Loop{
+	l := &Loop{
 		Render:            Render,
 		Simulate:          Simulate,
 		SimulationLatency: SimulationLatency,
@@ -61,22 +181,65 @@ func NewLoop(Render, Simulate LoopFn, RenderLatency, SimulationLatency time.Dura
 		runOnce:           sync.Once{},
 		doneSignal:        make(chan interface{}),
 		done:              make(chan interface{}),
+		exited:            make(chan interface{}),
 		err:               nil,
 		heartbeat:         make(chan LatencySample),
+		events:            make(chan Event, eventBufferSize),
+		eventFilter:       allEvents,
+		spikes:            make(chan SpikeEvent, eventBufferSize),
+		stalls:            make(chan StallEvent, eventBufferSize),
+		hints:             make(chan PresentHint, 1),
+		ready:             make(chan struct{}),
+		forced:            make(chan chan error),
+		advance:           make(chan chan error),
+		timeScale:         1,
+		spikeThreshold:    defaultSpikeThreshold,
+		healthRed:         defaultHealthRed,
+		maxObservers:      defaultMaxObservers,
 		curState:          stateInit,
-	}, nil
+	}
+
+	for _, opt := range opts {
+		if err := opt(l); err != nil {
+			return nil, err
+		}
+	}
+	if l.renderDriven && l.concurrentRender {
+		return nil, wrapLoopError(nil, TokenLoop, "A render driven loop can't render concurrently")
+	}
+	if l.presets != nil && l.concurrentRender {
+		return nil, wrapLoopError(nil, TokenLoop, "A rate ladder can't be used while rendering concurrently")
+	}
+	if l.runOnCaller && l.concurrentRender {
+		return nil, wrapLoopError(nil, TokenLoop, "Callbacks can't all run on the Run goroutine while rendering concurrently")
+	}
+	return l, nil
 }
 
 // Heartbeat returns the heartbeat channel which
 // can be used to monitor the health of the game loop.
 // A pulse will be sent every second with current simulation
 // and render latency.
+// The channel is closed once the loop is finished, always after
+// Done() closes. A bare receive on the closed channel gets a zero
+// LatencySample, so receive with "sample, ok := <-loop.Heartbeat()"
+// and stop once ok is false, or range over the channel.
+// Samples still buffered by WithHeartbeatBuffer are received, with
+// ok set, before that.
 func (l *Loop) Heartbeat() <-chan LatencySample {
 	l.mu.Lock()
 	defer l.mu.Unlock()
 	return l.heartbeat
 }
 
+// TryHeartbeat returns the most recent heartbeat sample without blocking.
+// It returns false if no sample has been taken yet.
+func (l *Loop) TryHeartbeat() (LatencySample, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.lastBeat, l.hasBeat
+}
+
 // Done returns a chan that indicates when the loop is stopped.
 // When this finishes, you should do cleanup.
 func (l *Loop) Done() <-chan interface{} {
@@ -88,23 +251,29 @@ func (l *Loop) Done() <-chan interface{} {
 // Stop halts the loop and sets Err().
 // You probably want to make a call to this somewhere in Simulate().
 func (l *Loop) Stop(err error) {
+	l.mu.Lock()
+	prev := l.curState
+	if prev == stateStop {
+		l.mu.Unlock()
+		return
+	}
+	// Mark the loop stopped before mapping the error,
+	// so a mapper that calls Stop returns right away.
+	l.curState = stateStop
+	mapper := l.stopErrorMapper
+	l.mu.Unlock()
+
+	err = mapStopError(mapper, err)
+
 	l.mu.Lock()
 	defer l.mu.Unlock()
-	switch l.curState {
-	case stateInit:
+	l.err = err
+	close(l.done)
+	// If we are running, make the loop goroutine close the reporting chan.
+	// I want to guarantee that render or simulate will not be called once
+	// Done() closes.
+	if prev == stateInit {
 		l.signalDone()
-		close(l.done)
-		l.err = err
-		l.curState = stateStop
-	case stateRun:
-		// If we are running, make the loop goroutine close the reporting chan.
-		// I want to guarantee that render or simulate will not be called once
-		// Done() closes.
-		close(l.done)
-		l.err = err
-		l.curState = stateStop
-	case stateStop:
-		return
 	}
 }
 
@@ -121,6 +290,36 @@ func (l *Loop) signalDone() {
 	l.runOnce.Do(func() { close(l.doneSignal) })
 }
 
+// closeChannels closes every output channel the loop owns.
+func (l *Loop) closeChannels() {
+	l.heartbeatOnce.Do(func() { close(l.heartbeat) })
+	l.eventsOnce.Do(func() { close(l.events) })
+	l.spikesOnce.Do(func() { close(l.spikes) })
+	l.stallsOnce.Do(func() { close(l.stalls) })
+	l.hintsOnce.Do(func() { close(l.hints) })
+}
+
+// Close releases everything the loop owns once it has stopped.
+// It waits for the loop goroutine to exit, and closes the heartbeat
+// channel if that hasn't happened already.
+// Close returns an error if the loop has not been stopped.
+// It is safe to call more than once.
+func (l *Loop) Close() error {
+	l.mu.Lock()
+	if l.curState != stateStop {
+		l.mu.Unlock()
+		return wrapLoopError(nil, TokenLoop, "Loop must be stopped before it is closed")
+	}
+	started := l.started
+	l.mu.Unlock()
+
+	if started {
+		<-l.exited
+	}
+	l.closeChannels()
+	return nil
+}
+
 // Start initiates a game loop. This call does not block.
 // To stop the loop, close the done chan.
 // To get notified before Simulate or Render are called, pull items from
@@ -133,103 +332,33 @@ func (l *Loop) Start() error {
 
 	var wg sync.WaitGroup
 	wg.Add(1)
+	if err := l.begin(); err != nil {
+		return err
+	}
+
+	go newRunner(l).run(&wg)
+	// Don't return until timer loop goroutine is actually starting.
+	wg.Wait()
+	return nil
+}
+
+// begin moves the loop into the running state. l.mu must be held.
+func (l *Loop) begin() error {
 	// Silently fail on re-starts.
 	if l.curState != stateInit {
 		return wrapLoopError(nil, TokenLoop, "Loop is already running or is done")
 	}
 	l.curState = stateRun
-
-	go func() {
-		// Stats heartbeat channel set up
-		heartTick := time.NewTicker(time.Second)
-		sendBeat := func(ps LatencySample) {
-			select {
-			case l.heartbeat <- ps:
-			default: // Throw it away if no one is listening.
-			}
-		}
-
-		// simTick has an internal limiter, and I need to make sure the
-		// delay isn't accidentally doubled.
-		simChan := time.NewTimer(time.Duration(0))
-		// rendTick has no internal limiter, the Ticker controls
-		// the execution rate.
-		rendTick := time.NewTicker(l.RenderLatency)
-
-		defer simChan.Stop()
-		defer rendTick.Stop()
-		defer heartTick.Stop()
-		defer close(l.heartbeat)
-		defer l.Stop(nil)
-
-		// Time tracking.
-		simAccumulator := time.Duration(0)
-		now := time.Now()
-		simLatency := newLatencyTracker()
-		previousSim := now
-		rendLatency := newLatencyTracker()
-		previousRend := now
-
-		wg.Done()
-
-		for {
-			select {
-			case <-l.doneSignal:
-				break
-			case <-l.done:
-				l.signalDone()
-				break
-			case <-heartTick.C:
-				sendBeat(LatencySample{
-					RenderLatency:   rendLatency.Latency(),
-					SimulateLatency: simLatency.Latency(),
-				})
-			case <-simChan.C:
-				// How much are we behind?
-				curTime := time.Now()
-				frameTime := curTime.Sub(previousSim)
-				previousSim = curTime
-				simAccumulator += frameTime
-				// Call simulate() if we built up enough lag.
-				for simAccumulator >= l.SimulationLatency {
-					// Run the simulation with a fixed step.
-
-					// Actually call simulate...
-					if er := l.Simulate(l.SimulationLatency); er != nil {
-						wrapped := wrapLoopError(er, TokenSimulate, "Error returned by Simulate(%s)", l.SimulationLatency.String())
-						wrapped.Misc["curTime"] = curTime
-						l.Stop(wrapped)
-						break
-					}
-
-					simLatency.MarkDone(l.SimulationLatency)
-
-					// Keep track of leftover time.
-					simAccumulator -= l.SimulationLatency
-				}
-				// Set up next call to simulate()...
-				simChan.Reset(l.SimulationLatency - simAccumulator)
-			case <-rendTick.C:
-				// How much are we behind?
-				curTime := time.Now()
-				frameTime := curTime.Sub(previousRend)
-				previousRend = curTime
-
-				// Call render() if we built up enough lag.
-				// Unlike simulate(), we can skip calls by varying the input time delta.
-				// Actually call render...
-				if er := l.Render(frameTime); er != nil {
-					wrapped := wrapLoopError(er, TokenRender, "Error returned by Render(%s)", frameTime.String())
-					wrapped.Misc["curTime"] = curTime
-					l.Stop(wrapped)
-					break
-				}
-
-				rendLatency.MarkDone(frameTime)
-			}
-		}
-	}()
-	// Don't return until timer loop goroutine is actually starting.
-	wg.Wait()
+	l.started = true
 	return nil
 }
+
+// stopping is true once Stop has been called.
+func (l *Loop) stopping() bool {
+	select {
+	case <-l.done:
+		return true
+	default:
+		return false
+	}
+}
@@ -1,6 +1,8 @@
 package gloop
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"runtime/debug"
 )
@@ -27,3 +29,60 @@ func wrapLoopError(err error, source TokenSource, messagef string, msgArgs ...in
 func (e LoopError) Error() string {
 	return e.Message
 }
+
+// loopErrorJSON is how a LoopError looks as JSON.
+type loopErrorJSON struct {
+	Source     string                     `json:"source"`
+	Message    string                     `json:"message"`
+	StackTrace string                     `json:"stackTrace"`
+	Inner      []string                   `json:"inner,omitempty"`
+	Misc       map[string]json.RawMessage `json:"misc,omitempty"`
+}
+
+// MarshalJSON encodes the error for crash reporting.
+// ErrorSource is encoded by name, and Inner as the message of each
+// error in its chain, outermost first. Misc values that can't be
+// encoded as JSON are encoded as their fmt.Sprint string instead.
+func (e LoopError) MarshalJSON() ([]byte, error) {
+	out := loopErrorJSON{
+		Source:     e.ErrorSource.String(),
+		Message:    e.Message,
+		StackTrace: e.StackTrace,
+	}
+	for inner := e.Inner; inner != nil; inner = errors.Unwrap(inner) {
+		out.Inner = append(out.Inner, inner.Error())
+	}
+	if len(e.Misc) > 0 {
+		out.Misc = make(map[string]json.RawMessage, len(e.Misc))
+		for key, value := range e.Misc {
+			raw, err := json.Marshal(value)
+			if err != nil {
+				raw, _ = json.Marshal(fmt.Sprint(value))
+			}
+			out.Misc[key] = raw
+		}
+	}
+	return json.Marshal(out)
+}
+
+// WithStopErrorMapper passes the LoopError that stopped the loop through
+// mapper, and Err() returns whatever mapper returns. Use it to turn loop
+// errors into your application's own error types.
+// mapper is called once, on the goroutine that called Stop. Errors passed
+// to Stop that aren't a LoopError, including nil, are left alone.
+// Calling Stop from inside mapper does nothing.
+func WithStopErrorMapper(mapper func(LoopError) error) Option {
+	return func(l *Loop) error {
+		l.stopErrorMapper = mapper
+		return nil
+	}
+}
+
+// mapStopError applies mapper to err if it is a LoopError.
+func mapStopError(mapper func(LoopError) error, err error) error {
+	var loopErr LoopError
+	if mapper == nil || !errors.As(err, &loopErr) {
+		return err
+	}
+	return mapper(loopErr)
+}
@@ -1,5 +1,9 @@
 package gloop
 
+import (
+	"fmt"
+)
+
 // TokenSource is the creator for the token (metrics or errors).
 type TokenSource int
 
@@ -11,3 +15,16 @@ const (
 	// TokenSimulate concerns Simulate().
 	TokenSimulate TokenSource = iota
 )
+
+// String is the name of the source, like "render".
+func (s TokenSource) String() string {
+	switch s {
+	case TokenLoop:
+		return "loop"
+	case TokenRender:
+		return "render"
+	case TokenSimulate:
+		return "simulate"
+	}
+	return fmt.Sprintf("TokenSource(%d)", int(s))
+}
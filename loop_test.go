@@ -1,6 +1,8 @@
 package gloop_test
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"testing"
 	"time"
@@ -86,6 +88,31 @@ func TestDoubleStop(t *testing.T) {
 	assert.Nil(t, loop.Err())
 }
 
+func TestGoroutineExitsAfterStop(t *testing.T) {
+	nothing := func(step time.Duration) error {
+		return nil
+	}
+	loop, err := gloop.NewLoop(nothing, nothing, gloop.Hz60Delay, gloop.Hz60Delay)
+	assert.Nil(t, err)
+	assert.Nil(t, loop.Start())
+	loop.Stop(nil)
+	<-loop.Done()
+
+	// The heartbeat channel is closed as the loop goroutine exits.
+	heartbeat := loop.Heartbeat()
+	timeout := time.After(time.Second)
+	for {
+		select {
+		case _, ok := <-heartbeat:
+			if !ok {
+				return
+			}
+		case <-timeout:
+			t.Fatal("loop goroutine is still running after Stop")
+		}
+	}
+}
+
 func TestRenderError(t *testing.T) {
 	render := func(step time.Duration) error {
 		return fmt.Errorf("Intentional error")
@@ -139,3 +166,383 @@ func TestMetricPublication(t *testing.T) {
 
 	assert.NotNil(t, sample)
 }
+
+func TestRenderWithDeadline(t *testing.T) {
+	var remaining time.Duration
+	simulate := func(step time.Duration) error {
+		return nil
+	}
+	loop, err := gloop.NewLoop(nil, simulate, gloop.Hz60Delay, gloop.Hz60Delay)
+	assert.Nil(t, err)
+	assert.NotNil(t, loop)
+	loop.RenderWithDeadline = func(step time.Duration, deadline time.Time) error {
+		remaining = deadline.Sub(time.Now())
+		loop.Stop(nil)
+		return nil
+	}
+	err = loop.Start()
+	assert.Nil(t, err)
+	<-loop.Done()
+	assert.Nil(t, loop.Err())
+
+	// The deadline is measured from when the tick fired,
+	// so allow for some scheduling delay.
+	assert.True(t, remaining <= gloop.Hz60Delay)
+	assert.True(t, remaining > -gloop.Hz60Delay)
+}
+
+func TestTryHeartbeat(t *testing.T) {
+	render := func(step time.Duration) error {
+		return nil
+	}
+	simulate := func(step time.Duration) error {
+		return nil
+	}
+	loop, err := gloop.NewLoop(render, simulate, gloop.Hz60Delay, gloop.Hz60Delay)
+	assert.Nil(t, err)
+	assert.NotNil(t, loop)
+
+	_, ok := loop.TryHeartbeat()
+	assert.False(t, ok)
+
+	err = loop.Start()
+	assert.Nil(t, err)
+	sample := <-loop.Heartbeat()
+
+	latest, ok := loop.TryHeartbeat()
+	assert.True(t, ok)
+	assert.Equal(t, sample, latest)
+
+	loop.Stop(nil)
+	<-loop.Done()
+	assert.Nil(t, loop.Err())
+}
+
+func TestUtilization(t *testing.T) {
+	render := func(step time.Duration) error {
+		time.Sleep(gloop.Hz60Delay / 2)
+		return nil
+	}
+	simulate := func(step time.Duration) error {
+		return nil
+	}
+	loop, err := gloop.NewLoop(render, simulate, gloop.Hz60Delay, gloop.Hz60Delay)
+	assert.Nil(t, err)
+	assert.NotNil(t, loop)
+	err = loop.Start()
+	assert.Nil(t, err)
+
+	sample := <-loop.Heartbeat()
+
+	loop.Stop(nil)
+	<-loop.Done()
+	assert.Nil(t, loop.Err())
+
+	assert.InDelta(t, 0.5, sample.Utilization, 0.15)
+}
+
+func TestClose(t *testing.T) {
+	render := func(step time.Duration) error {
+		return nil
+	}
+	simulate := func(step time.Duration) error {
+		return nil
+	}
+	loop, err := gloop.NewLoop(render, simulate, gloop.Hz60Delay, gloop.Hz60Delay)
+	assert.Nil(t, err)
+	assert.NotNil(t, loop)
+	err = loop.Start()
+	assert.Nil(t, err)
+
+	// Can't close a running loop.
+	assert.NotNil(t, loop.Close())
+
+	loop.Stop(nil)
+	assert.Nil(t, loop.Close())
+	assert.Nil(t, loop.Close())
+
+	_, ok := <-loop.Heartbeat()
+	assert.False(t, ok)
+	assert.Nil(t, loop.Err())
+}
+
+func TestCloseUnstarted(t *testing.T) {
+	loop, err := gloop.NewLoop(nil, nil, gloop.Hz60Delay, gloop.Hz60Delay)
+	assert.Nil(t, err)
+	assert.NotNil(t, loop)
+	loop.Stop(nil)
+	assert.Nil(t, loop.Close())
+
+	_, ok := <-loop.Heartbeat()
+	assert.False(t, ok)
+}
+
+func TestNoCatchUp(t *testing.T) {
+	render := func(step time.Duration) error {
+		return nil
+	}
+	var calls []time.Time
+	loop, err := gloop.NewLoop(render, nil, gloop.Hz60Delay, gloop.Hz60Delay, gloop.WithNoCatchUp())
+	assert.Nil(t, err)
+	assert.NotNil(t, loop)
+	loop.Simulate = func(step time.Duration) error {
+		calls = append(calls, time.Now())
+		if len(calls) == 1 {
+			// Stall long enough to need several catch-up steps.
+			time.Sleep(5 * gloop.Hz60Delay)
+		}
+		if len(calls) == 10 {
+			loop.Stop(nil)
+		}
+		return nil
+	}
+	err = loop.Start()
+	assert.Nil(t, err)
+	<-loop.Done()
+	assert.Nil(t, loop.Err())
+
+	// Without catch-up, each step gets a frame of its own.
+	for i := 2; i < len(calls); i++ {
+		assert.True(t, calls[i].Sub(calls[i-1]) > gloop.Hz60Delay/2)
+	}
+}
+
+// stallingLoop records the order callbacks run in.
+// Every third render stalls, so both callbacks are due together.
+func stallingLoop(t *testing.T, opts ...gloop.Option) []string {
+	var order []string
+	loop, err := gloop.NewLoop(nil, nil, gloop.Hz60Delay, gloop.Hz60Delay, opts...)
+	assert.Nil(t, err)
+	assert.NotNil(t, loop)
+	renders := 0
+	loop.Render = func(step time.Duration) error {
+		order = append(order, "render")
+		renders++
+		if renders%3 == 0 {
+			time.Sleep(2 * gloop.Hz60Delay)
+		}
+		if renders == 12 {
+			loop.Stop(nil)
+		}
+		return nil
+	}
+	loop.Simulate = func(step time.Duration) error {
+		order = append(order, "simulate")
+		return nil
+	}
+	err = loop.Start()
+	assert.Nil(t, err)
+	<-loop.Done()
+	assert.Nil(t, loop.Err())
+	return order
+}
+
+func TestSimulatePriority(t *testing.T) {
+	order := stallingLoop(t)
+	renders := 0
+	for i, name := range order[:len(order)-1] {
+		if name == "render" {
+			renders++
+			if renders%3 == 0 {
+				assert.Equal(t, "simulate", order[i+1])
+			}
+		}
+	}
+}
+
+func TestRenderPriority(t *testing.T) {
+	order := stallingLoop(t, gloop.WithRenderPriority())
+	renders := 0
+	for i, name := range order[:len(order)-1] {
+		if name == "render" {
+			renders++
+			if renders%3 == 0 {
+				assert.Equal(t, "render", order[i+1])
+			}
+		}
+	}
+}
+
+func TestHeartbeatWhileOverloaded(t *testing.T) {
+	// Render never keeps up, so a frame is always due.
+	render := func(step time.Duration) error {
+		time.Sleep(2 * gloop.Hz60Delay)
+		return nil
+	}
+	simulate := func(step time.Duration) error { return nil }
+	loop, err := gloop.NewLoop(render, simulate, gloop.Hz60Delay, gloop.Hz60Delay)
+	assert.Nil(t, err)
+	assert.Nil(t, loop.Start())
+	defer func() {
+		loop.Stop(nil)
+		<-loop.Done()
+	}()
+
+	select {
+	case <-loop.Heartbeat():
+	case <-time.After(3 * time.Second):
+		assert.Fail(t, "no heartbeat while overloaded")
+	}
+
+	forced := make(chan error, 1)
+	go func() { forced <- loop.ForceTick() }()
+	select {
+	case err := <-forced:
+		assert.Nil(t, err)
+	case <-time.After(3 * time.Second):
+		assert.Fail(t, "ForceTick never ran while overloaded")
+	}
+}
+
+func TestMaxSimStepsPerFrame(t *testing.T) {
+	render := func(step time.Duration) error {
+		return nil
+	}
+	var calls []time.Time
+	loop, err := gloop.NewLoop(render, nil, gloop.Hz60Delay, gloop.Hz60Delay, gloop.WithMaxSimStepsPerFrame(3))
+	assert.Nil(t, err)
+	assert.NotNil(t, loop)
+	loop.Simulate = func(step time.Duration) error {
+		calls = append(calls, time.Now())
+		if len(calls) == 1 {
+			// Stall long enough to need 10 catch-up steps.
+			// The extra half step keeps the next frame from
+			// landing right after the catch-up burst.
+			time.Sleep(10*gloop.Hz60Delay + gloop.Hz60Delay/2)
+		}
+		if len(calls) == 20 {
+			loop.Stop(nil)
+		}
+		return nil
+	}
+	err = loop.Start()
+	assert.Nil(t, err)
+	<-loop.Done()
+	assert.Nil(t, loop.Err())
+
+	// Steps in the same frame run back to back, while separate
+	// frames are at least a few milliseconds apart even when late.
+	burst := 1
+	for i := 2; i < len(calls); i++ {
+		if calls[i].Sub(calls[i-1]) < time.Millisecond {
+			burst++
+		} else {
+			burst = 1
+		}
+		assert.True(t, burst <= 3)
+	}
+}
+
+func TestSimHooks(t *testing.T) {
+	var calls []string
+	var ticks []uint64
+	before := func(tick uint64) {
+		calls = append(calls, "before")
+		ticks = append(ticks, tick)
+	}
+	after := func(tick uint64) {
+		calls = append(calls, "after")
+		assert.Equal(t, ticks[len(ticks)-1], tick)
+	}
+	loop, err := gloop.NewLoop(nil, nil, gloop.Hz60Delay, gloop.Hz60Delay, gloop.WithSimHooks(before, after))
+	assert.Nil(t, err)
+	assert.NotNil(t, loop)
+	loop.Simulate = func(step time.Duration) error {
+		calls = append(calls, "simulate")
+		return nil
+	}
+
+	assert.Nil(t, loop.StepN(3))
+	assert.Equal(t, []string{
+		"before", "simulate", "after",
+		"before", "simulate", "after",
+		"before", "simulate", "after",
+	}, calls)
+	assert.Equal(t, []uint64{1, 2, 3}, ticks)
+}
+
+type appError struct {
+	source gloop.TokenSource
+	cause  string
+}
+
+func (e appError) Error() string {
+	return e.cause
+}
+
+func TestStopErrorMapper(t *testing.T) {
+	var loop *gloop.Loop
+	mapper := func(err gloop.LoopError) error {
+		// This must not deadlock or recurse.
+		loop.Stop(nil)
+		return appError{source: err.ErrorSource, cause: err.Inner.Error()}
+	}
+	render := func(step time.Duration) error {
+		return errors.New("out of video memory")
+	}
+	loop, err := gloop.NewLoop(render, nil, gloop.Hz60Delay, gloop.Hz60Delay, gloop.WithStopErrorMapper(mapper))
+	assert.Nil(t, err)
+	assert.Nil(t, loop.Start())
+	<-loop.Done()
+
+	var mapped appError
+	assert.True(t, errors.As(loop.Err(), &mapped))
+	assert.Equal(t, gloop.TokenRender, mapped.source)
+	assert.Equal(t, "out of video memory", mapped.cause)
+}
+
+func TestLoopErrorJSON(t *testing.T) {
+	render := func(step time.Duration) error {
+		return fmt.Errorf("frame 12: %w", errors.New("out of video memory"))
+	}
+	loop, err := gloop.NewLoop(render, nil, gloop.Hz60Delay, gloop.Hz60Delay)
+	assert.Nil(t, err)
+	assert.Nil(t, loop.Start())
+	<-loop.Done()
+
+	loopErr, ok := loop.Err().(gloop.LoopError)
+	assert.True(t, ok)
+	loopErr.Misc["frame"] = 12
+	loopErr.Misc["callback"] = render
+
+	raw, err := json.Marshal(loopErr)
+	assert.Nil(t, err)
+	var decoded struct {
+		Source     string
+		Message    string
+		StackTrace string
+		Inner      []string
+		Misc       map[string]interface{}
+	}
+	assert.Nil(t, json.Unmarshal(raw, &decoded))
+	assert.Equal(t, "render", decoded.Source)
+	assert.Equal(t, loopErr.Message, decoded.Message)
+	assert.Contains(t, decoded.StackTrace, "gloop")
+	assert.Equal(t, []string{"frame 12: out of video memory", "out of video memory"}, decoded.Inner)
+	assert.Equal(t, 12.0, decoded.Misc["frame"])
+	// Funcs can't be JSON, so they are printed instead.
+	assert.IsType(t, "", decoded.Misc["callback"])
+}
+
+func TestHeartbeatClosesAfterDone(t *testing.T) {
+	loop, err := gloop.NewLoop(nil, nil, gloop.Hz60Delay, gloop.Hz60Delay)
+	assert.Nil(t, err)
+
+	closed := make(chan bool)
+	heartbeat := loop.Heartbeat()
+	go func() {
+		for range heartbeat {
+		}
+		// The heartbeat is closed, so Done() must be too.
+		select {
+		case <-loop.Done():
+			closed <- true
+		default:
+			closed <- false
+		}
+	}()
+
+	assert.Nil(t, loop.Start())
+	loop.Stop(nil)
+	assert.True(t, <-closed)
+}
@@ -0,0 +1,106 @@
+package gloop
+
+import (
+	"sort"
+	"sync"
+)
+
+// defaultMaxObservers is how many Subscribe observers a loop
+// allows at once, unless WithMaxObservers says otherwise.
+const defaultMaxObservers = 1024
+
+type observer struct {
+	id       uint64
+	priority int
+	fn       func(LatencySample)
+}
+
+// WithMaxObservers caps how many Subscribe observers can be registered
+// at once. Past the cap, Subscribe returns an error, which catches
+// code that subscribes in a loop and never unsubscribes.
+// The default is 1024.
+func WithMaxObservers(n int) Option {
+	return func(l *Loop) error {
+		if n <= 0 {
+			return wrapLoopError(nil, TokenLoop, "MaxObservers can't be lte 0")
+		}
+		l.maxObservers = n
+		return nil
+	}
+}
+
+// Subscribe calls fn with every heartbeat sample, on the loop goroutine,
+// until the returned unsubscribe func is called.
+// Observers are called in the order they subscribed, after any with a
+// lower priority from SubscribePriority. Subscribe uses priority 0.
+// fn should return quickly, since the loop waits for it.
+// Unsubscribe is safe to call more than once, from any goroutine,
+// and after the loop stops.
+// It returns an error if WithMaxObservers observers are already registered.
+func (l *Loop) Subscribe(fn func(LatencySample)) (unsubscribe func(), err error) {
+	return l.SubscribePriority(fn, 0)
+}
+
+// SubscribePriority is Subscribe, with control over the order observers
+// are called in. Lower priorities are called first, so a logger at -1
+// sees each sample before an exporter at 0. Observers with the same
+// priority are called in the order they subscribed.
+func (l *Loop) SubscribePriority(fn func(LatencySample), priority int) (unsubscribe func(), err error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if len(l.observers) >= l.maxObservers {
+		return nil, wrapLoopError(nil, TokenLoop, "Can't have more than %d observers", l.maxObservers)
+	}
+
+	l.nextObs++
+	id := l.nextObs
+	// Copy on write so the loop can iterate without holding the lock.
+	observers := make([]observer, len(l.observers), len(l.observers)+1)
+	copy(observers, l.observers)
+	observers = append(observers, observer{id: id, priority: priority, fn: fn})
+	sort.SliceStable(observers, func(i, j int) bool {
+		return observers[i].priority < observers[j].priority
+	})
+	l.observers = observers
+
+	var once sync.Once
+	return func() {
+		once.Do(func() { l.unsubscribe(id) })
+	}, nil
+}
+
+func (l *Loop) unsubscribe(id uint64) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	observers := make([]observer, 0, len(l.observers))
+	for _, o := range l.observers {
+		if o.id != id {
+			observers = append(observers, o)
+		}
+	}
+	l.observers = observers
+}
+
+// DetachObservers unsubscribes every observer at once, including the
+// one ExportCSV uses, so code that is hot-reloaded can subscribe again
+// without leaking the old observers. Their unsubscribe funcs become
+// no-ops. It is safe to call while the loop runs, but, as with
+// unsubscribe, a sample that is already being handed out may still
+// reach them.
+func (l *Loop) DetachObservers() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.observers = nil
+}
+
+func (l *Loop) notifyObservers(sample LatencySample) {
+	l.mu.Lock()
+	observers := l.observers
+	l.mu.Unlock()
+
+	for _, o := range observers {
+		o.fn(sample)
+	}
+}
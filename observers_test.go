@@ -0,0 +1,130 @@
+package gloop_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/erinpentecost/gloop"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSubscribe(t *testing.T) {
+	render := func(step time.Duration) error {
+		return nil
+	}
+	simulate := func(step time.Duration) error {
+		return nil
+	}
+	loop, err := gloop.NewLoop(render, simulate, gloop.Hz60Delay, gloop.Hz60Delay)
+	assert.Nil(t, err)
+	assert.NotNil(t, loop)
+
+	samples := make(chan gloop.LatencySample, 10)
+	unsubscribe, err := loop.Subscribe(func(sample gloop.LatencySample) {
+		samples <- sample
+	})
+	assert.Nil(t, err)
+	err = loop.Start()
+	assert.Nil(t, err)
+
+	<-samples
+	unsubscribe()
+	// Observers run before the heartbeat is sent.
+	<-loop.Heartbeat()
+	assert.Equal(t, 0, len(samples))
+
+	loop.Stop(nil)
+	<-loop.Done()
+	assert.Nil(t, loop.Err())
+	unsubscribe()
+}
+
+func TestMaxObservers(t *testing.T) {
+	loop, err := gloop.NewLoop(nil, nil, gloop.Hz60Delay, gloop.Hz60Delay, gloop.WithMaxObservers(3))
+	assert.Nil(t, err)
+
+	var unsubscribes []func()
+	for i := 0; i < 3; i++ {
+		unsubscribe, err := loop.Subscribe(func(gloop.LatencySample) {})
+		assert.Nil(t, err)
+		unsubscribes = append(unsubscribes, unsubscribe)
+	}
+	unsubscribe, err := loop.Subscribe(func(gloop.LatencySample) {})
+	assert.NotNil(t, err)
+	assert.Nil(t, unsubscribe)
+
+	// Unsubscribing makes room again.
+	unsubscribes[0]()
+	_, err = loop.Subscribe(func(gloop.LatencySample) {})
+	assert.Nil(t, err)
+
+	_, err = gloop.NewLoop(nil, nil, gloop.Hz60Delay, gloop.Hz60Delay, gloop.WithMaxObservers(0))
+	assert.NotNil(t, err)
+}
+
+func TestSubscribePriority(t *testing.T) {
+	loop, err := gloop.NewLoop(nil, nil, gloop.Hz60Delay, gloop.Hz60Delay)
+	assert.Nil(t, err)
+
+	order := make(chan string, 10)
+	observe := func(name string) func(gloop.LatencySample) {
+		return func(gloop.LatencySample) { order <- name }
+	}
+	_, err = loop.Subscribe(observe("exporter"))
+	assert.Nil(t, err)
+	_, err = loop.SubscribePriority(observe("late"), 5)
+	assert.Nil(t, err)
+	_, err = loop.Subscribe(observe("exporter 2"))
+	assert.Nil(t, err)
+	_, err = loop.SubscribePriority(observe("logger"), -1)
+	assert.Nil(t, err)
+
+	assert.Nil(t, loop.Start())
+	<-loop.Heartbeat()
+	loop.Stop(nil)
+	<-loop.Done()
+
+	var got []string
+	for i := 0; i < 4; i++ {
+		got = append(got, <-order)
+	}
+	assert.Equal(t, []string{"logger", "exporter", "exporter 2", "late"}, got)
+}
+
+func TestDetachObservers(t *testing.T) {
+	loop, err := gloop.NewLoop(nil, nil, gloop.Hz60Delay, gloop.Hz60Delay)
+	assert.Nil(t, err)
+
+	first := make(chan gloop.LatencySample, 10)
+	second := make(chan gloop.LatencySample, 10)
+	unsubscribe, err := loop.Subscribe(func(sample gloop.LatencySample) {
+		first <- sample
+	})
+	assert.Nil(t, err)
+	_, err = loop.SubscribePriority(func(sample gloop.LatencySample) {
+		second <- sample
+	}, 1)
+	assert.Nil(t, err)
+	assert.Nil(t, loop.Start())
+
+	<-first
+	<-second
+	loop.DetachObservers()
+	// Observers run before the heartbeat is sent.
+	<-loop.Heartbeat()
+	assert.Equal(t, 0, len(first))
+	assert.Equal(t, 0, len(second))
+
+	// Old unsubscribe funcs are harmless, and new observers work.
+	unsubscribe()
+	again := make(chan gloop.LatencySample, 10)
+	_, err = loop.Subscribe(func(sample gloop.LatencySample) {
+		again <- sample
+	})
+	assert.Nil(t, err)
+	<-again
+
+	loop.Stop(nil)
+	<-loop.Done()
+	assert.Nil(t, loop.Err())
+}
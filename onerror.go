@@ -0,0 +1,27 @@
+package gloop
+
+// OnError calls fn with every error returned by Render, Simulate or a
+// render layer, as soon as it happens and before the loop stops.
+// fn runs on the goroutine that called the failing callback, so it
+// sees the error even before Done() closes. Add as many as you like;
+// they are called in the order they were added.
+// Errors passed directly to Stop aren't reported here.
+func (l *Loop) OnError(fn func(LoopError)) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	// Copy on write so errors can be reported without holding the lock.
+	onError := make([]func(LoopError), len(l.onError), len(l.onError)+1)
+	copy(onError, l.onError)
+	l.onError = append(onError, fn)
+}
+
+// reportError passes err to every OnError observer.
+func (l *Loop) reportError(err LoopError) {
+	l.mu.Lock()
+	onError := l.onError
+	l.mu.Unlock()
+
+	for _, fn := range onError {
+		fn(err)
+	}
+}
@@ -0,0 +1,54 @@
+package gloop_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/erinpentecost/gloop"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOnError(t *testing.T) {
+	simulate := func(step time.Duration) error {
+		return errors.New("bad tick")
+	}
+	loop, err := gloop.NewLoop(nil, simulate, gloop.Hz60Delay, gloop.Hz60Delay)
+	assert.Nil(t, err)
+
+	var seen []string
+	for _, name := range []string{"first", "second"} {
+		name := name
+		loop.OnError(func(err gloop.LoopError) {
+			assert.Equal(t, gloop.TokenSimulate, err.ErrorSource)
+			assert.Equal(t, "bad tick", err.Inner.Error())
+			// The loop hasn't torn down yet.
+			select {
+			case <-loop.Done():
+				t.Error("Done closed before OnError")
+			default:
+			}
+			seen = append(seen, name)
+		})
+	}
+
+	assert.Nil(t, loop.Start())
+	<-loop.Done()
+	assert.NotNil(t, loop.Err())
+	assert.Equal(t, []string{"first", "second"}, seen)
+}
+
+func TestOnErrorStepMode(t *testing.T) {
+	render := func(step time.Duration) error {
+		return errors.New("bad frame")
+	}
+	loop, err := gloop.NewLoop(render, nil, gloop.Hz60Delay, gloop.Hz60Delay, gloop.WithStepRender())
+	assert.Nil(t, err)
+
+	var sources []gloop.TokenSource
+	loop.OnError(func(err gloop.LoopError) {
+		sources = append(sources, err.ErrorSource)
+	})
+	assert.NotNil(t, loop.StepN(1))
+	assert.Equal(t, []gloop.TokenSource{gloop.TokenRender}, sources)
+}
@@ -0,0 +1,50 @@
+package gloop
+
+// Option configures optional behavior of a Loop.
+// Pass options to NewLoop.
+type Option func(*Loop) error
+
+// WithNoCatchUp runs at most one Simulate step per frame.
+// If the loop falls behind, the backlog is thrown away, so the
+// simulation runs in slow motion instead of catching up.
+func WithNoCatchUp() Option {
+	return func(l *Loop) error {
+		l.noCatchUp = true
+		return nil
+	}
+}
+
+// WithMaxSimStepsPerFrame caps how many Simulate steps can run to catch up
+// in a single frame. The step stays SimulationLatency, but whole steps past
+// the cap are thrown away instead of carried over, which keeps a slow frame
+// from snowballing. By default there is no cap.
+func WithMaxSimStepsPerFrame(n int) Option {
+	return func(l *Loop) error {
+		if n <= 0 {
+			return wrapLoopError(nil, TokenLoop, "MaxSimStepsPerFrame can't be lte 0")
+		}
+		l.maxSimSteps = n
+		return nil
+	}
+}
+
+// WithRenderPriority runs Render before Simulate when both are due
+// at the same time. By default, Simulate goes first.
+func WithRenderPriority() Option {
+	return func(l *Loop) error {
+		l.renderFirst = true
+		return nil
+	}
+}
+
+// WithSimHooks calls before and after around each Simulate step,
+// for lightweight instrumentation like tracing spans.
+// tick counts Simulate calls, starting at 1.
+// Hooks can't stop the loop. Either hook may be nil.
+func WithSimHooks(before, after func(tick uint64)) Option {
+	return func(l *Loop) error {
+		l.beforeSim = before
+		l.afterSim = after
+		return nil
+	}
+}
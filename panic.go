@@ -0,0 +1,56 @@
+package gloop
+
+// WithPanicHandler recovers panics in Simulate, Render and render
+// layers, and passes them to handler along with where they came from,
+// so they can be logged or sent on to a crash reporter.
+// After handler returns, the panic is turned into a LoopError that
+// stops the loop, with the recovered value in Misc["recovered"].
+// Pass WithRepanic as well to panic again instead, with the original
+// stack still in place.
+// Without this option, panics aren't recovered.
+func WithPanicHandler(handler func(recovered interface{}, source TokenSource)) Option {
+	return func(l *Loop) error {
+		if handler == nil {
+			return wrapLoopError(nil, TokenLoop, "PanicHandler can't be nil")
+		}
+		l.panicHandler = handler
+		return nil
+	}
+}
+
+// WithRepanic makes a panic carry on after the WithPanicHandler
+// handler has seen it, instead of being turned into an error.
+func WithRepanic() Option {
+	return func(l *Loop) error {
+		l.repanic = true
+		return nil
+	}
+}
+
+// recovering wraps fn, the callback for region, so that any panic
+// goes to the panic handler.
+func (l *Loop) recovering(region string, fn func() error) func() error {
+	if l.panicHandler == nil {
+		return fn
+	}
+	source, name := TokenRender, "Render"
+	if region == simulateRegion {
+		source, name = TokenSimulate, "Simulate"
+	}
+	return func() (err error) {
+		defer func() {
+			recovered := recover()
+			if recovered == nil {
+				return
+			}
+			l.panicHandler(recovered, source)
+			if l.repanic {
+				panic(recovered)
+			}
+			wrapped := wrapLoopError(nil, source, "%s panicked: %v", name, recovered)
+			wrapped.Misc["recovered"] = recovered
+			err = wrapped
+		}()
+		return fn()
+	}
+}
@@ -0,0 +1,67 @@
+package gloop_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/erinpentecost/gloop"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPanicHandler(t *testing.T) {
+	var recovered []interface{}
+	var sources []gloop.TokenSource
+	handler := func(value interface{}, source gloop.TokenSource) {
+		recovered = append(recovered, value)
+		sources = append(sources, source)
+	}
+	simulate := func(step time.Duration) error {
+		panic("boom")
+	}
+	loop, err := gloop.NewLoop(nil, simulate, gloop.Hz60Delay, gloop.Hz60Delay,
+		gloop.WithPanicHandler(handler))
+	assert.Nil(t, err)
+
+	assert.Nil(t, loop.Start())
+	<-loop.Done()
+
+	assert.Equal(t, []interface{}{"boom"}, recovered)
+	assert.Equal(t, []gloop.TokenSource{gloop.TokenSimulate}, sources)
+
+	// The panic became the error that stopped the loop.
+	var loopErr gloop.LoopError
+	assert.True(t, errors.As(loop.Err(), &loopErr))
+	assert.Equal(t, gloop.TokenSimulate, loopErr.ErrorSource)
+	var inner gloop.LoopError
+	assert.True(t, errors.As(loopErr.Inner, &inner))
+	assert.Equal(t, "boom", inner.Misc["recovered"])
+}
+
+func TestPanicHandlerRepanic(t *testing.T) {
+	var sources []gloop.TokenSource
+	handler := func(value interface{}, source gloop.TokenSource) {
+		sources = append(sources, source)
+	}
+	render := func(step time.Duration) error {
+		panic("boom")
+	}
+	// Running on this goroutine lets the test catch the panic.
+	loop, err := gloop.NewLoop(render, nil, gloop.Hz60Delay, gloop.Hz60Delay,
+		gloop.WithPanicHandler(handler), gloop.WithRepanic(),
+		gloop.WithCallbacksOnRunGoroutine())
+	assert.Nil(t, err)
+
+	var recovered interface{}
+	func() {
+		defer func() {
+			recovered = recover()
+		}()
+		_ = loop.Run()
+	}()
+
+	assert.Equal(t, "boom", recovered)
+	assert.Equal(t, []gloop.TokenSource{gloop.TokenRender}, sources)
+	// The loop still shut down.
+	<-loop.Done()
+}
@@ -0,0 +1,117 @@
+package gloop
+
+import (
+	"sync"
+	"time"
+)
+
+// PauseSimulation stops Simulate from being called, while Render keeps
+// running at its usual rate. Time doesn't build up while paused, so
+// there is no catch-up on resume. Alpha() holds its last value, and on
+// resume restarts from the leftover simulation time, so it stays in
+// [0,1). The same goes for Suspend, FreezeFrame and AcquirePause.
+// This is useful for a level editor, where the world is frozen but the
+// camera still moves.
+func (l *Loop) PauseSimulation() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.simPaused = true
+}
+
+// ResumeSimulation undoes PauseSimulation. It doesn't release any
+// PauseToken, so the simulation stays paused while one is held.
+func (l *Loop) ResumeSimulation() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.simPaused {
+		l.simPaused = false
+		l.resumed()
+	}
+}
+
+// SimulationPaused is true between PauseSimulation and ResumeSimulation,
+// or while any PauseToken is held.
+func (l *Loop) SimulationPaused() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.paused()
+}
+
+// paused is SimulationPaused for callers that already hold l.mu.
+func (l *Loop) paused() bool {
+	return l.simPaused || l.pauseRefs > 0
+}
+
+// PauseToken holds the simulation paused until it is released.
+// Get one from AcquirePause.
+type PauseToken struct {
+	loop *Loop
+	once *sync.Once
+}
+
+// AcquirePause pauses the simulation, like PauseSimulation, but counts
+// holders: the simulation stays paused until every token is released.
+// This lets a menu, a dialog and a loading screen each pause the game
+// without resuming it out from under each other.
+func (l *Loop) AcquirePause() PauseToken {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.pauseRefs++
+	return PauseToken{loop: l, once: &sync.Once{}}
+}
+
+// Release gives up the token's hold on the pause. Calling it more than
+// once does nothing, and so does calling it on a zero PauseToken.
+func (t PauseToken) Release() {
+	if t.loop == nil {
+		return
+	}
+	t.once.Do(func() {
+		t.loop.mu.Lock()
+		defer t.loop.mu.Unlock()
+		t.loop.pauseRefs--
+		if t.loop.pauseRefs == 0 {
+			t.loop.resumed()
+		}
+	})
+}
+
+// resumed records that a pause, suspend or freeze was lifted, so the
+// next frames rebaseline. The caller must hold l.mu.
+func (l *Loop) resumed() {
+	l.resumes++
+}
+
+// resumeCount is how many times a pause, suspend or freeze was lifted.
+func (l *Loop) resumeCount() uint64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.resumes
+}
+
+// rebaseResumeSimulation treats curTime as caught up after a resume,
+// so none of the time spent stopped is simulated.
+func (r *runner) rebaseResumeSimulation(curTime time.Time) {
+	count := r.l.resumeCount()
+	if count == r.simResume {
+		return
+	}
+	r.simResume = count
+	r.previousSim = curTime
+	r.publishSimClock(0)
+}
+
+// rebaseResumeRender restarts interpolation from curTime after a resume,
+// so the first alpha is the accumulator's residual and not whatever the
+// clock published before the pause has drifted to.
+func (r *runner) rebaseResumeRender(curTime time.Time) {
+	count := r.l.resumeCount()
+	if count == r.rendResume {
+		return
+	}
+	r.rendResume = count
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.simClock.at = curTime
+	r.simClock.scale = 0
+}
@@ -0,0 +1,127 @@
+package gloop_test
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/erinpentecost/gloop"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPauseSimulation(t *testing.T) {
+	simulations := 0
+	simulate := func(step time.Duration) error {
+		simulations++
+		return nil
+	}
+	loop, err := gloop.NewLoop(nil, simulate, gloop.Hz60Delay, gloop.Hz60Delay)
+	assert.Nil(t, err)
+	assert.NotNil(t, loop)
+	renders := 0
+	var alphas []float64
+	loop.Render = func(step time.Duration) error {
+		renders++
+		alphas = append(alphas, loop.Alpha())
+		switch renders {
+		case 10:
+			// Render kept going while simulate was paused.
+			assert.Equal(t, 0, simulations)
+			loop.ResumeSimulation()
+		case 20:
+			loop.Stop(nil)
+		}
+		return nil
+	}
+	loop.PauseSimulation()
+	assert.True(t, loop.SimulationPaused())
+
+	err = loop.Start()
+	assert.Nil(t, err)
+	<-loop.Done()
+	assert.Nil(t, loop.Err())
+
+	assert.False(t, loop.SimulationPaused())
+	assert.True(t, simulations > 0)
+	// Resuming doesn't make up for the paused time.
+	assert.True(t, simulations <= 12)
+	for _, alpha := range alphas[:10] {
+		assert.Equal(t, 0.0, alpha)
+	}
+}
+
+func TestAcquirePause(t *testing.T) {
+	loop, err := gloop.NewLoop(nil, nil, gloop.Hz60Delay, gloop.Hz60Delay)
+	assert.Nil(t, err)
+
+	first := loop.AcquirePause()
+	second := loop.AcquirePause()
+	assert.True(t, loop.SimulationPaused())
+
+	first.Release()
+	assert.True(t, loop.SimulationPaused())
+	// Releasing the same token again doesn't release the other.
+	first.Release()
+	assert.True(t, loop.SimulationPaused())
+
+	// ResumeSimulation doesn't override a held token.
+	loop.ResumeSimulation()
+	assert.True(t, loop.SimulationPaused())
+
+	second.Release()
+	assert.False(t, loop.SimulationPaused())
+}
+
+// firstAlphaAfterResume runs a loop, stops it with halt for several
+// render intervals, lifts it with resume, and returns the alpha seen
+// by the first render after that.
+func firstAlphaAfterResume(t *testing.T, halt, resume func(loop *gloop.Loop)) float64 {
+	const latency = 10 * time.Millisecond
+	nothing := func(step time.Duration) error {
+		return nil
+	}
+	loop, err := gloop.NewLoop(nil, nothing, latency, 3*latency)
+	assert.Nil(t, err)
+
+	var resumed int32
+	alphas := make(chan float64, 1)
+	loop.Render = func(step time.Duration) error {
+		if atomic.LoadInt32(&resumed) == 0 {
+			return nil
+		}
+		select {
+		case alphas <- loop.Alpha():
+		default:
+		}
+		return nil
+	}
+	assert.Nil(t, loop.Start())
+	defer func() {
+		loop.Stop(nil)
+		<-loop.Done()
+	}()
+
+	time.Sleep(5 * latency)
+	halt(loop)
+	time.Sleep(5 * latency)
+	atomic.StoreInt32(&resumed, 1)
+	resume(loop)
+	return <-alphas
+}
+
+func TestAlphaAfterResume(t *testing.T) {
+	alpha := firstAlphaAfterResume(t, (*gloop.Loop).PauseSimulation, (*gloop.Loop).ResumeSimulation)
+	assert.True(t, alpha >= 0 && alpha < 1)
+
+	alpha = firstAlphaAfterResume(t, (*gloop.Loop).Suspend, (*gloop.Loop).Resume)
+	assert.True(t, alpha >= 0 && alpha < 1)
+
+	alpha = firstAlphaAfterResume(t, (*gloop.Loop).FreezeFrame, (*gloop.Loop).Unfreeze)
+	assert.True(t, alpha >= 0 && alpha < 1)
+
+	var token gloop.PauseToken
+	alpha = firstAlphaAfterResume(t,
+		func(loop *gloop.Loop) { token = loop.AcquirePause() },
+		func(loop *gloop.Loop) { token.Release() })
+	assert.True(t, alpha >= 0 && alpha < 1)
+}
@@ -0,0 +1,20 @@
+package gloop
+
+import (
+	"time"
+)
+
+// WithSimulationPhaseOffset holds back the first simulate frame by d,
+// and every one after it, so that several loops running at the same
+// rate on one machine can be staggered instead of all simulating at
+// once. For example, give each of four 60 Hz loops an offset a quarter
+// of Hz60Delay apart. It has no effect on a render driven loop.
+func WithSimulationPhaseOffset(d time.Duration) Option {
+	return func(l *Loop) error {
+		if d < 0 {
+			return wrapLoopError(nil, TokenLoop, "SimulationPhaseOffset can't be lt 0")
+		}
+		l.simPhase = d
+		return nil
+	}
+}
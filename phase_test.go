@@ -0,0 +1,52 @@
+package gloop_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/erinpentecost/gloop"
+	"github.com/stretchr/testify/assert"
+)
+
+// tickTimes starts a loop and records when each of its first n
+// simulate steps ran.
+func tickTimes(t *testing.T, wg *sync.WaitGroup, n int, latency time.Duration, opts ...gloop.Option) *[]time.Time {
+	var times []time.Time
+	loop, err := gloop.NewLoop(nil, nil, time.Second, latency, opts...)
+	assert.Nil(t, err)
+	loop.Simulate = func(step time.Duration) error {
+		times = append(times, time.Now())
+		if len(times) == n {
+			loop.Stop(nil)
+		}
+		return nil
+	}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		<-loop.Done()
+	}()
+	assert.Nil(t, loop.Start())
+	return &times
+}
+
+func TestSimulationPhaseOffset(t *testing.T) {
+	const latency = 40 * time.Millisecond
+	const offset = latency / 2
+	var wg sync.WaitGroup
+	first := tickTimes(t, &wg, 5, latency)
+	second := tickTimes(t, &wg, 5, latency, gloop.WithSimulationPhaseOffset(offset))
+	wg.Wait()
+
+	var total time.Duration
+	for i := range *first {
+		total += (*second)[i].Sub((*first)[i])
+	}
+	shift := total / time.Duration(len(*first))
+	assert.True(t, shift > offset/2, "shift %s", shift)
+	assert.True(t, shift < offset*3/2, "shift %s", shift)
+
+	_, err := gloop.NewLoop(nil, nil, latency, latency, gloop.WithSimulationPhaseOffset(-1))
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,43 @@
+package gloop
+
+import (
+	"time"
+)
+
+// WithMaxPhysicsStep keeps every step passed to Simulate at or under d.
+// A simulation step longer than d is split into as few equal sub-steps
+// as fit, and Simulate is called once for each, back to back. This
+// keeps integrators stable when SimulationLatency is long.
+// Sub-steps add up to the whole step exactly. They count as a single
+// tick in Stats() and WithSimHooks, and an error from any of them
+// ends the tick.
+func WithMaxPhysicsStep(d time.Duration) Option {
+	return func(l *Loop) error {
+		if d <= 0 {
+			return wrapLoopError(nil, TokenLoop, "MaxPhysicsStep can't be lte 0")
+		}
+		l.maxPhysicsStep = d
+		return nil
+	}
+}
+
+// simulateSubSteps calls Simulate for step, split up per WithMaxPhysicsStep.
+func (l *Loop) simulateSubSteps(step time.Duration) error {
+	limit := l.maxPhysicsStep
+	if limit <= 0 || step <= limit {
+		return l.Simulate(step)
+	}
+	n := (step + limit - 1) / limit
+	base, extra := step/n, step%n
+	for i := time.Duration(0); i < n; i++ {
+		sub := base
+		// Spread the remainder a nanosecond at a time.
+		if i < extra {
+			sub++
+		}
+		if err := l.Simulate(sub); err != nil {
+			return err
+		}
+	}
+	return nil
+}
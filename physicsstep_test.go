@@ -0,0 +1,50 @@
+package gloop_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/erinpentecost/gloop"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMaxPhysicsStep(t *testing.T) {
+	var steps []time.Duration
+	simulate := func(step time.Duration) error {
+		steps = append(steps, step)
+		return nil
+	}
+	loop, err := gloop.NewLoop(nil, simulate, gloop.Hz60Delay, 50*time.Millisecond, gloop.WithMaxPhysicsStep(16*time.Millisecond))
+	assert.Nil(t, err)
+
+	assert.Nil(t, loop.StepN(2))
+	// Each 50ms step is four 12.5ms sub-steps.
+	assert.Len(t, steps, 8)
+	for _, step := range steps {
+		assert.Equal(t, 12500*time.Microsecond, step)
+	}
+	assert.Equal(t, uint64(2), loop.Stats().SimTicks)
+}
+
+func TestMaxPhysicsStepRemainder(t *testing.T) {
+	var steps []time.Duration
+	simulate := func(step time.Duration) error {
+		steps = append(steps, step)
+		return nil
+	}
+	// 1/60s doesn't split evenly in three.
+	loop, err := gloop.NewLoop(nil, simulate, gloop.Hz60Delay, gloop.Hz60Delay, gloop.WithMaxPhysicsStep(6*time.Millisecond))
+	assert.Nil(t, err)
+
+	assert.Nil(t, loop.StepN(1))
+	assert.Len(t, steps, 3)
+	var total time.Duration
+	for _, step := range steps {
+		assert.True(t, step <= 6*time.Millisecond)
+		total += step
+	}
+	assert.Equal(t, gloop.Hz60Delay, total)
+
+	_, err = gloop.NewLoop(nil, nil, gloop.Hz60Delay, gloop.Hz60Delay, gloop.WithMaxPhysicsStep(0))
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,39 @@
+package gloop
+
+import (
+	"time"
+)
+
+// PresentHint is sent just before each render so a renderer
+// that syncs to the display can line up its buffer swaps.
+type PresentHint struct {
+	// Present is when the frame being rendered should be shown.
+	// This is when the next render is due.
+	Present time.Time
+	// Alpha is the interpolation alpha for the frame.
+	Alpha float64
+}
+
+// PresentHints returns a channel that gets a hint before each render.
+// Only the latest hint is kept if no one is reading.
+// The channel is closed when the loop stops.
+func (l *Loop) PresentHints() <-chan PresentHint {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.hints
+}
+
+func (l *Loop) hint(h PresentHint) {
+	for {
+		select {
+		case l.hints <- h:
+			return
+		default:
+		}
+		// Make room by throwing away the stale hint.
+		select {
+		case <-l.hints:
+		default:
+		}
+	}
+}
@@ -0,0 +1,36 @@
+package gloop_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/erinpentecost/gloop"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPresentHints(t *testing.T) {
+	simulate := func(step time.Duration) error {
+		return nil
+	}
+	loop, err := gloop.NewLoop(nil, simulate, gloop.Hz60Delay, gloop.Hz60Delay)
+	assert.Nil(t, err)
+	assert.NotNil(t, loop)
+	renders := 0
+	loop.Render = func(step time.Duration) error {
+		renders++
+		hint := <-loop.PresentHints()
+		now := time.Now()
+		assert.True(t, hint.Present.Sub(now) <= gloop.Hz60Delay)
+		assert.True(t, now.Sub(hint.Present) < gloop.Hz60Delay)
+		assert.Equal(t, loop.Alpha(), hint.Alpha)
+		if renders == 5 {
+			loop.Stop(nil)
+		}
+		return nil
+	}
+	err = loop.Start()
+	assert.Nil(t, err)
+	<-loop.Done()
+	assert.Nil(t, loop.Err())
+	assert.Equal(t, 5, renders)
+}
@@ -0,0 +1,27 @@
+//go:build linux
+// +build linux
+
+package gloop
+
+import (
+	"syscall"
+)
+
+// threadNice is the nice value asked for by WithLockOSThread.
+// Lowering it needs CAP_SYS_NICE, so it often fails, and that's fine.
+const threadNice = -5
+
+func platformRaisePriority() func() {
+	tid := syscall.Gettid()
+	// The raw syscall returns 20 - nice.
+	old, err := syscall.Getpriority(syscall.PRIO_PROCESS, tid)
+	if err != nil {
+		return func() {}
+	}
+	if syscall.Setpriority(syscall.PRIO_PROCESS, tid, threadNice) != nil {
+		return func() {}
+	}
+	return func() {
+		syscall.Setpriority(syscall.PRIO_PROCESS, tid, 20-old)
+	}
+}
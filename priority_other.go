@@ -0,0 +1,8 @@
+//go:build !linux && !windows
+// +build !linux,!windows
+
+package gloop
+
+// Thread priority is left alone everywhere else.
+
+func platformRaisePriority() func() { return func() {} }
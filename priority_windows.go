@@ -0,0 +1,32 @@
+//go:build windows
+// +build windows
+
+package gloop
+
+import (
+	"syscall"
+)
+
+var (
+	kernel32          = syscall.NewLazyDLL("kernel32.dll")
+	getCurrentThread  = kernel32.NewProc("GetCurrentThread")
+	getThreadPriority = kernel32.NewProc("GetThreadPriority")
+	setThreadPriority = kernel32.NewProc("SetThreadPriority")
+)
+
+// threadPriorityAboveNormal is THREAD_PRIORITY_ABOVE_NORMAL.
+const threadPriorityAboveNormal = 1
+
+func platformRaisePriority() func() {
+	if setThreadPriority.Find() != nil || getThreadPriority.Find() != nil {
+		return func() {}
+	}
+	thread, _, _ := getCurrentThread.Call()
+	old, _, _ := getThreadPriority.Call(thread)
+	if ok, _, _ := setThreadPriority.Call(thread, uintptr(threadPriorityAboveNormal)); ok == 0 {
+		return func() {}
+	}
+	return func() {
+		setThreadPriority.Call(thread, old)
+	}
+}
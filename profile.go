@@ -0,0 +1,36 @@
+package gloop
+
+import (
+	"context"
+	"io"
+	"runtime/pprof"
+	"time"
+)
+
+// Goroutine labels applied while Render or Simulate are running,
+// so CPU profiles can tell the two apart.
+var (
+	unlabeled      = context.Background()
+	renderLabels   = pprof.WithLabels(unlabeled, pprof.Labels("gloop", "render"))
+	simulateLabels = pprof.WithLabels(unlabeled, pprof.Labels("gloop", "simulate"))
+)
+
+// ProfileTo records a CPU profile for duration d and writes it to w.
+// Samples taken inside Render and Simulate are labeled with
+// gloop=render and gloop=simulate respectively.
+// This call blocks until d elapses or the loop finishes, whichever is first.
+// Only one CPU profile can be active per process.
+func (l *Loop) ProfileTo(w io.Writer, d time.Duration) error {
+	if err := pprof.StartCPUProfile(w); err != nil {
+		return wrapLoopError(err, TokenLoop, "Failed to start CPU profile")
+	}
+	defer pprof.StopCPUProfile()
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+	case <-l.Done():
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package gloop_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/erinpentecost/gloop"
+	"github.com/google/pprof/profile"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProfileTo(t *testing.T) {
+	spin := func(step time.Duration) error {
+		end := time.Now().Add(step / 4)
+		for time.Now().Before(end) {
+		}
+		return nil
+	}
+	loop, err := gloop.NewLoop(spin, spin, gloop.Hz60Delay, gloop.Hz60Delay)
+	assert.Nil(t, err)
+	err = loop.Start()
+	assert.Nil(t, err)
+
+	var buf bytes.Buffer
+	err = loop.ProfileTo(&buf, 200*time.Millisecond)
+	assert.Nil(t, err)
+
+	loop.Stop(nil)
+	<-loop.Done()
+
+	prof, err := profile.Parse(&buf)
+	assert.Nil(t, err)
+	labels := map[string]int{}
+	for _, sample := range prof.Sample {
+		for _, value := range sample.Label["gloop"] {
+			labels[value]++
+		}
+	}
+	assert.True(t, labels["render"] > 0, "labels: %v", labels)
+	assert.True(t, labels["simulate"] > 0, "labels: %v", labels)
+}
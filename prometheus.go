@@ -0,0 +1,46 @@
+package gloop
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// promMetric is one metric written by WritePrometheus.
+type promMetric struct {
+	name, kind, help string
+	value            float64
+}
+
+// WritePrometheus writes the loop's metrics to w in the Prometheus text
+// exposition format, so they can be served from a plain http.HandlerFunc
+// without the Prometheus client library. Latencies and utilization come
+// from the latest heartbeat, and are 0 until the first one. Totals come
+// from Stats(). Every metric name starts with "gloop_".
+func (l *Loop) WritePrometheus(w io.Writer) error {
+	sample, _ := l.TryHeartbeat()
+	stats := l.Stats()
+	metrics := []promMetric{
+		{"gloop_render_latency_seconds", "gauge", "How far behind wall time rendering is.", sample.RenderLatency.Seconds()},
+		{"gloop_simulate_latency_seconds", "gauge", "How far behind wall time the simulation is.", sample.SimulateLatency.Seconds()},
+		{"gloop_utilization_ratio", "gauge", "Fraction of time spent in callbacks.", sample.Utilization},
+		{"gloop_sim_ticks_total", "counter", "Simulate calls.", float64(stats.SimTicks)},
+		{"gloop_render_frames_total", "counter", "Render frames.", float64(stats.RenderFrames)},
+		{"gloop_dropped_sim_ticks_total", "counter", "Simulation steps thrown away.", float64(stats.TotalDroppedSimTicks)},
+		{"gloop_dropped_render_frames_total", "counter", "Render ticks skipped.", float64(stats.TotalDroppedRenderFrames)},
+		{"gloop_overruns_total", "counter", "Callbacks that took longer than their latency.", float64(stats.TotalOverruns)},
+		{"gloop_spikes_total", "counter", "Frames reported on Spikes().", float64(stats.TotalSpikes)},
+	}
+
+	out := bufio.NewWriter(w)
+	for _, m := range metrics {
+		fmt.Fprintf(out, "# HELP %s %s\n", m.name, m.help)
+		fmt.Fprintf(out, "# TYPE %s %s\n", m.name, m.kind)
+		fmt.Fprintf(out, "%s %s\n", m.name, strconv.FormatFloat(m.value, 'g', -1, 64))
+	}
+	if err := out.Flush(); err != nil {
+		return wrapLoopError(err, TokenLoop, "Failed to write Prometheus metrics")
+	}
+	return nil
+}
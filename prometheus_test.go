@@ -0,0 +1,76 @@
+package gloop_test
+
+import (
+	"bufio"
+	"bytes"
+	"regexp"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/erinpentecost/gloop"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	promHelp   = regexp.MustCompile(`^# HELP ([a-zA-Z_:][a-zA-Z0-9_:]*) .+$`)
+	promType   = regexp.MustCompile(`^# TYPE ([a-zA-Z_:][a-zA-Z0-9_:]*) (counter|gauge|histogram|summary|untyped)$`)
+	promSample = regexp.MustCompile(`^([a-zA-Z_:][a-zA-Z0-9_:]*) (\S+)$`)
+)
+
+// parseProm checks text against the Prometheus text format, for the
+// simple case of samples without labels, and returns each value.
+func parseProm(t *testing.T, text []byte) map[string]float64 {
+	values := map[string]float64{}
+	typed := map[string]bool{}
+	scanner := bufio.NewScanner(bytes.NewReader(text))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if m := promHelp.FindStringSubmatch(line); m != nil {
+			continue
+		}
+		if m := promType.FindStringSubmatch(line); m != nil {
+			// TYPE comes once, before the samples.
+			assert.False(t, typed[m[1]], line)
+			_, sampled := values[m[1]]
+			assert.False(t, sampled, line)
+			typed[m[1]] = true
+			continue
+		}
+		m := promSample.FindStringSubmatch(line)
+		if !assert.NotNil(t, m, "bad line %q", line) {
+			continue
+		}
+		assert.True(t, typed[m[1]], "untyped sample %q", line)
+		value, err := strconv.ParseFloat(m[2], 64)
+		assert.Nil(t, err, line)
+		values[m[1]] = value
+	}
+	return values
+}
+
+func TestWritePrometheus(t *testing.T) {
+	nothing := func(step time.Duration) error { return nil }
+	loop, err := gloop.NewLoop(nothing, nothing, gloop.Hz60Delay, gloop.Hz60Delay)
+	assert.Nil(t, err)
+	assert.Nil(t, loop.StepN(7))
+
+	var buf bytes.Buffer
+	assert.Nil(t, loop.WritePrometheus(&buf))
+	values := parseProm(t, buf.Bytes())
+
+	for _, name := range []string{
+		"gloop_render_latency_seconds",
+		"gloop_simulate_latency_seconds",
+		"gloop_utilization_ratio",
+		"gloop_render_frames_total",
+		"gloop_dropped_sim_ticks_total",
+		"gloop_dropped_render_frames_total",
+		"gloop_overruns_total",
+		"gloop_spikes_total",
+	} {
+		_, ok := values[name]
+		assert.True(t, ok, name)
+	}
+	assert.Equal(t, 7.0, values["gloop_sim_ticks_total"])
+}
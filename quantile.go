@@ -0,0 +1,83 @@
+package gloop
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// quantileAccuracy is the relative error of LifetimeQuantile.
+const quantileAccuracy = 0.01
+
+// quantileGamma is the ratio between neighboring bucket bounds.
+var quantileGamma = (1 + quantileAccuracy) / (1 - quantileAccuracy)
+
+// quantileSketch counts durations in buckets whose bounds grow
+// geometrically, so any quantile can be estimated to within
+// quantileAccuracy. Only a few thousand buckets can ever be used,
+// however many durations are added.
+type quantileSketch struct {
+	buckets map[int]uint64
+	count   uint64
+}
+
+func bucketOf(d time.Duration) int {
+	if d < 1 {
+		d = 1
+	}
+	return int(math.Ceil(math.Log(float64(d)) / math.Log(quantileGamma)))
+}
+
+func (s *quantileSketch) add(d time.Duration) {
+	if s.buckets == nil {
+		s.buckets = make(map[int]uint64)
+	}
+	s.buckets[bucketOf(d)]++
+	s.count++
+}
+
+// quantile estimates the q quantile, or returns 0 if nothing was added.
+func (s *quantileSketch) quantile(q float64) time.Duration {
+	if s.count == 0 {
+		return 0
+	}
+	keys := make([]int, 0, len(s.buckets))
+	for k := range s.buckets {
+		keys = append(keys, k)
+	}
+	sort.Ints(keys)
+
+	rank := uint64(q * float64(s.count-1))
+	var seen uint64
+	for _, k := range keys {
+		seen += s.buckets[k]
+		if seen > rank {
+			// The middle of the bucket, in relative terms.
+			return time.Duration(2 * math.Pow(quantileGamma, float64(k)) / (quantileGamma + 1))
+		}
+	}
+	return 0
+}
+
+// LifetimeQuantile estimates the q quantile, from 0 to 1, of how long
+// Render or Simulate has taken over the whole life of the loop.
+// The estimate is within 1% of the true value, and memory use stays
+// small no matter how long the loop runs.
+// source must be TokenRender or TokenSimulate. It returns 0 if the
+// callback hasn't run yet.
+func (l *Loop) LifetimeQuantile(source TokenSource, q float64) time.Duration {
+	if q < 0 {
+		q = 0
+	} else if q > 1 {
+		q = 1
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	switch source {
+	case TokenRender:
+		return l.renderSketch.quantile(q)
+	case TokenSimulate:
+		return l.simSketch.quantile(q)
+	}
+	return 0
+}
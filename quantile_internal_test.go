@@ -0,0 +1,35 @@
+package gloop
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQuantileSketchMatchesBruteForce(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	var sketch quantileSketch
+	samples := make([]time.Duration, 0, 100000)
+	for i := 0; i < cap(samples); i++ {
+		// Mostly around 2ms, with a long tail.
+		d := time.Duration(rng.ExpFloat64()*float64(2*time.Millisecond)) + 50*time.Microsecond
+		samples = append(samples, d)
+		sketch.add(d)
+	}
+	sort.Slice(samples, func(i, j int) bool { return samples[i] < samples[j] })
+
+	for _, q := range []float64{0, 0.1, 0.5, 0.9, 0.95, 0.99, 0.999, 1} {
+		want := samples[int(q*float64(len(samples)-1))]
+		got := sketch.quantile(q)
+		assert.InEpsilon(t, float64(want), float64(got), 2*quantileAccuracy, "q=%v", q)
+	}
+	assert.True(t, len(sketch.buckets) < 2000)
+}
+
+func TestQuantileSketchEmpty(t *testing.T) {
+	var sketch quantileSketch
+	assert.Equal(t, time.Duration(0), sketch.quantile(0.5))
+}
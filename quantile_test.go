@@ -0,0 +1,34 @@
+package gloop_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/erinpentecost/gloop"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLifetimeQuantile(t *testing.T) {
+	step := 0
+	simulate := func(d time.Duration) error {
+		step++
+		// Every tenth step is slow.
+		if step%10 == 0 {
+			time.Sleep(2 * time.Millisecond)
+		}
+		return nil
+	}
+	render := func(d time.Duration) error { return nil }
+	loop, err := gloop.NewLoop(render, simulate, gloop.Hz60Delay, gloop.Hz60Delay)
+	assert.Nil(t, err)
+
+	assert.Equal(t, time.Duration(0), loop.LifetimeQuantile(gloop.TokenSimulate, 0.5))
+
+	assert.Nil(t, loop.StepN(100))
+
+	median := loop.LifetimeQuantile(gloop.TokenSimulate, 0.5)
+	tail := loop.LifetimeQuantile(gloop.TokenSimulate, 0.99)
+	assert.True(t, median < time.Millisecond, "median %v", median)
+	assert.True(t, tail > 1900*time.Microsecond, "p99 %v", tail)
+	assert.Equal(t, time.Duration(0), loop.LifetimeQuantile(gloop.TokenLoop, 0.5))
+}
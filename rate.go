@@ -0,0 +1,65 @@
+package gloop
+
+import (
+	"time"
+)
+
+// rationalRate is a simulation rate of num/den ticks per second.
+// Its period usually isn't a whole number of nanoseconds, so steps
+// are either base or base+1, spread out so they average to the exact
+// period, like the pixels of a Bresenham line.
+type rationalRate struct {
+	base time.Duration
+	// extra is how many nanoseconds, in units of 1/num,
+	// each step owes on top of base.
+	extra int64
+	num   int64
+}
+
+// WithSimulationRate has Simulate run exactly num/den times a second,
+// such as 60000/1001 for NTSC's 59.94 Hz. The step handed to Simulate
+// differs by at most a nanosecond from tick to tick, so that simulated
+// time never drifts from the exact rate.
+// It replaces the SimulationLatency passed to NewLoop with the shorter step.
+func WithSimulationRate(num, den int64) Option {
+	return func(l *Loop) error {
+		if num <= 0 || den <= 0 {
+			return wrapLoopError(nil, TokenLoop, "SimulationRate can't be lte 0")
+		}
+		period := den * int64(time.Second)
+		if period/int64(time.Second) != den || period < num {
+			return wrapLoopError(nil, TokenLoop, "SimulationRate %d/%d is out of range", num, den)
+		}
+		l.simRate = &rationalRate{
+			base:  time.Duration(period / num),
+			extra: period % num,
+			num:   num,
+		}
+		l.SimulationLatency = l.simRate.base
+		return nil
+	}
+}
+
+// simStep is the step for the next Simulate call.
+func (r *runner) simStep() time.Duration {
+	rate := r.l.simRate
+	if rate == nil {
+		return r.l.SimulationLatency
+	}
+	if r.rateRemainder+rate.extra >= rate.num {
+		return rate.base + 1
+	}
+	return rate.base
+}
+
+// advanceSimStep moves on to the next step, once Simulate was called.
+func (r *runner) advanceSimStep() {
+	rate := r.l.simRate
+	if rate == nil {
+		return
+	}
+	r.rateRemainder += rate.extra
+	if r.rateRemainder >= rate.num {
+		r.rateRemainder -= rate.num
+	}
+}
@@ -0,0 +1,54 @@
+package gloop_test
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/erinpentecost/gloop"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSimulationRate(t *testing.T) {
+	// NTSC is 60000/1001 Hz, so 60000 ticks take exactly 1001 seconds.
+	const num, den = 60000, 1001
+	var simulated time.Duration
+	ticks := int64(0)
+	simulate := func(step time.Duration) error {
+		simulated += step
+		ticks++
+		// Exact simulated time so far is ticks*den/num seconds.
+		exact := new(big.Rat).SetFrac64(ticks*den*int64(time.Second), num)
+		drift := new(big.Rat).Sub(new(big.Rat).SetInt64(int64(simulated)), exact)
+		f, _ := drift.Float64()
+		assert.True(t, f > -1 && f <= 0, "drift after %d ticks: %v", ticks, f)
+		return nil
+	}
+	loop, err := gloop.NewLoop(nil, simulate, gloop.Hz60Delay, gloop.Hz60Delay, gloop.WithSimulationRate(num, den))
+	assert.Nil(t, err)
+	assert.Equal(t, time.Duration(16683333), loop.SimulationLatency)
+
+	assert.Nil(t, loop.StepN(num))
+	assert.Equal(t, den*time.Second, simulated)
+}
+
+func TestSimulationRateValidation(t *testing.T) {
+	for _, rate := range [][2]int64{{0, 1}, {1, 0}, {-60, 1}, {2e9, 1}} {
+		_, err := gloop.NewLoop(nil, nil, gloop.Hz60Delay, gloop.Hz60Delay, gloop.WithSimulationRate(rate[0], rate[1]))
+		assert.NotNil(t, err)
+	}
+}
+
+func TestSimulationHzReplacesRate(t *testing.T) {
+	steps := map[time.Duration]int{}
+	simulate := func(step time.Duration) error {
+		steps[step]++
+		return nil
+	}
+	loop, err := gloop.NewLoop(nil, simulate, gloop.Hz60Delay, gloop.Hz60Delay,
+		gloop.WithSimulationRate(60000, 1001), gloop.WithSimulationHz(100))
+	assert.Nil(t, err)
+
+	assert.Nil(t, loop.StepN(10))
+	assert.Equal(t, map[time.Duration]int{10 * time.Millisecond: 10}, steps)
+}
@@ -0,0 +1,58 @@
+package gloop
+
+import (
+	"time"
+)
+
+// SetRenderRateLimit caps how many times a second Render is called,
+// below the rate set by RenderLatency, until the cap is cleared.
+// Use it as a temporary ceiling, for example when a phone reports
+// thermal pressure. It never raises the rate above RenderLatency.
+// Pass 0 to clear the cap. The change takes effect at the next render.
+func (l *Loop) SetRenderRateLimit(maxHz float64) error {
+	var limit time.Duration
+	if maxHz != 0 {
+		latency, err := hzToLatency(maxHz, "RenderRateLimit")
+		if err != nil {
+			return err
+		}
+		limit = latency
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.renderCap = limit
+	return nil
+}
+
+// RenderRateLimit is the cap set by SetRenderRateLimit, in Hz,
+// or 0 if there isn't one.
+func (l *Loop) RenderRateLimit() float64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.renderCap == 0 {
+		return 0
+	}
+	return float64(time.Second) / float64(l.renderCap)
+}
+
+// renderInterval is the time between render frames,
+// after any rate limit.
+func (l *Loop) renderInterval() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.renderCap > l.RenderLatency {
+		return l.renderCap
+	}
+	return l.RenderLatency
+}
+
+// applyRenderLimit moves the render ticker to the current
+// render interval, and returns it.
+func (r *runner) applyRenderLimit() time.Duration {
+	every := r.l.renderInterval()
+	if every != r.rendEvery {
+		r.rendEvery = every
+		r.rendTick.Reset(every)
+	}
+	return every
+}
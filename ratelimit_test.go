@@ -0,0 +1,58 @@
+package gloop_test
+
+import (
+	"math"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/erinpentecost/gloop"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderRateLimit(t *testing.T) {
+	var renders int32
+	render := func(step time.Duration) error {
+		atomic.AddInt32(&renders, 1)
+		return nil
+	}
+	loop, err := gloop.NewLoop(render, nil, 5*time.Millisecond, gloop.Hz60Delay)
+	assert.Nil(t, err)
+	assert.Equal(t, 0.0, loop.RenderRateLimit())
+
+	count := func(d time.Duration) int32 {
+		before := atomic.LoadInt32(&renders)
+		time.Sleep(d)
+		return atomic.LoadInt32(&renders) - before
+	}
+
+	assert.Nil(t, loop.Start())
+	defer func() {
+		loop.Stop(nil)
+		<-loop.Done()
+	}()
+
+	// 200 Hz until the cap.
+	assert.Nil(t, loop.SetRenderRateLimit(50))
+	assert.Equal(t, 50.0, loop.RenderRateLimit())
+	time.Sleep(50 * time.Millisecond)
+	limited := count(400 * time.Millisecond)
+	assert.True(t, limited >= 12 && limited <= 24, "renders while capped: %d", limited)
+
+	// A cap above the base rate does nothing.
+	assert.Nil(t, loop.SetRenderRateLimit(1000))
+	time.Sleep(50 * time.Millisecond)
+	uncapped := count(400 * time.Millisecond)
+	assert.True(t, uncapped >= 50, "renders under a high cap: %d", uncapped)
+
+	assert.Nil(t, loop.SetRenderRateLimit(50))
+	time.Sleep(50 * time.Millisecond)
+	assert.Nil(t, loop.SetRenderRateLimit(0))
+	time.Sleep(50 * time.Millisecond)
+	cleared := count(400 * time.Millisecond)
+	assert.True(t, cleared >= 50, "renders after clearing: %d", cleared)
+
+	assert.NotNil(t, loop.SetRenderRateLimit(-1))
+	assert.NotNil(t, loop.SetRenderRateLimit(math.NaN()))
+	assert.NotNil(t, loop.SetRenderRateLimit(math.Inf(1)))
+}
@@ -0,0 +1,13 @@
+package gloop
+
+// Ready returns a chan that closes once Simulate and Render have
+// each finished at least once. If the loop stops before then, it
+// never closes, so select on it alongside Done().
+func (l *Loop) Ready() <-chan struct{} {
+	return l.ready
+}
+
+// markReady closes the ready chan the first time it is called.
+func (l *Loop) markReady() {
+	l.readyOnce.Do(func() { close(l.ready) })
+}
@@ -0,0 +1,59 @@
+package gloop_test
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/erinpentecost/gloop"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReady(t *testing.T) {
+	var simulated, rendered int32
+	render := func(step time.Duration) error {
+		atomic.AddInt32(&rendered, 1)
+		return nil
+	}
+	simulate := func(step time.Duration) error {
+		atomic.AddInt32(&simulated, 1)
+		return nil
+	}
+	loop, err := gloop.NewLoop(render, simulate, gloop.Hz60Delay, gloop.Hz60Delay)
+	assert.Nil(t, err)
+
+	select {
+	case <-loop.Ready():
+		t.Fatal("ready before starting")
+	default:
+	}
+
+	assert.Nil(t, loop.Start())
+	select {
+	case <-loop.Ready():
+		assert.True(t, atomic.LoadInt32(&simulated) > 0)
+		assert.True(t, atomic.LoadInt32(&rendered) > 0)
+	case <-loop.Done():
+		t.Fatal("stopped before ready")
+	case <-time.After(time.Second):
+		t.Fatal("never ready")
+	}
+
+	loop.Stop(nil)
+	<-loop.Done()
+}
+
+func TestReadyNotWithoutRender(t *testing.T) {
+	simulate := func(step time.Duration) error {
+		return nil
+	}
+	loop, err := gloop.NewLoop(nil, simulate, gloop.Hz60Delay, gloop.Hz60Delay)
+	assert.Nil(t, err)
+	assert.Nil(t, loop.StepN(5))
+
+	select {
+	case <-loop.Ready():
+		t.Fatal("ready without a render")
+	default:
+	}
+}
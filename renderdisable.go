@@ -0,0 +1,53 @@
+package gloop
+
+import (
+	"time"
+)
+
+// WithRenderAutoDisable turns Render off, instead of stopping the loop,
+// once n render frames in a row have either overrun RenderLatency or
+// returned an error. WithHysteresis can change n. Simulate keeps
+// running. This keeps a headless machine, or one without a working
+// GPU, simulating.
+// EventRenderDisabled is sent when it happens.
+// With this option, render errors no longer stop the loop.
+func WithRenderAutoDisable(n int) Option {
+	return func(l *Loop) error {
+		if n <= 0 {
+			return wrapLoopError(nil, TokenLoop, "RenderAutoDisable can't be lte 0")
+		}
+		l.renderStrikes = n
+		return nil
+	}
+}
+
+// RenderDisabled is true once WithRenderAutoDisable has turned Render off.
+func (l *Loop) RenderDisabled() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.renderOff
+}
+
+// renderStrike counts a render frame towards WithRenderAutoDisable.
+// Frames that didn't fail count towards clearing it, per WithHysteresis.
+func (r *runner) renderStrike(failed bool, err error) {
+	l := r.l
+	if !r.renderHealth.observe(failed) || l.RenderDisabled() {
+		return
+	}
+
+	l.mu.Lock()
+	l.renderOff = true
+	l.mu.Unlock()
+	l.emit(Event{Kind: EventRenderDisabled, Time: time.Now(), Source: TokenRender, Err: err})
+}
+
+// skipRenderFrame stands in for a render frame once Render is off,
+// or while the loop is suspended.
+func (r *runner) skipRenderFrame() {
+	now := time.Now()
+	r.mu.Lock()
+	r.rendLatency.MarkDone(now.Sub(r.previousRend))
+	r.mu.Unlock()
+	r.previousRend = now
+}
@@ -0,0 +1,103 @@
+package gloop_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/erinpentecost/gloop"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderAutoDisable(t *testing.T) {
+	const renderLatency = 5 * time.Millisecond
+	renders, simulations := 0, 0
+	render := func(step time.Duration) error {
+		renders++
+		// Always overrun.
+		time.Sleep(2 * renderLatency)
+		return nil
+	}
+	simulate := func(step time.Duration) error {
+		simulations++
+		return nil
+	}
+	loop, err := gloop.NewLoop(render, simulate, renderLatency, renderLatency,
+		gloop.WithRenderAutoDisable(3), gloop.WithEventFilter(gloop.EventRenderDisabled))
+	assert.Nil(t, err)
+
+	assert.Nil(t, loop.Start())
+	event := <-loop.Events()
+	assert.Equal(t, gloop.EventRenderDisabled, event.Kind)
+	assert.Nil(t, event.Err)
+	assert.True(t, loop.RenderDisabled())
+
+	before := loop.Stats()
+	<-time.After(20 * renderLatency)
+	after := loop.Stats()
+	loop.Stop(nil)
+	<-loop.Done()
+	assert.Nil(t, loop.Err())
+
+	assert.Equal(t, 3, renders)
+	assert.Equal(t, before.RenderFrames, after.RenderFrames)
+	assert.True(t, after.SimTicks > before.SimTicks+10)
+}
+
+func TestRenderAutoDisableErrors(t *testing.T) {
+	failures := 0
+	render := func(step time.Duration) error {
+		failures++
+		return errors.New("no GPU")
+	}
+	loop, err := gloop.NewLoop(render, nil, gloop.Hz60Delay, gloop.Hz60Delay,
+		gloop.WithRenderAutoDisable(2), gloop.WithEventFilter(gloop.EventRenderDisabled))
+	assert.Nil(t, err)
+
+	assert.Nil(t, loop.Start())
+	event := <-loop.Events()
+	assert.NotNil(t, event.Err)
+	loop.Stop(nil)
+	<-loop.Done()
+
+	assert.Nil(t, loop.Err())
+	assert.Equal(t, 2, failures)
+}
+
+func TestRenderAutoDisableValidation(t *testing.T) {
+	_, err := gloop.NewLoop(nil, nil, gloop.Hz60Delay, gloop.Hz60Delay, gloop.WithRenderAutoDisable(0))
+	assert.NotNil(t, err)
+}
+
+func TestRenderAutoDisableHysteresis(t *testing.T) {
+	const renderLatency = 5 * time.Millisecond
+	renders := 0
+	render := func(step time.Duration) error {
+		renders++
+		// A single spike, then a sustained overrun.
+		if renders == 3 || renders >= 10 {
+			time.Sleep(2 * renderLatency)
+		}
+		return nil
+	}
+	loop, err := gloop.NewLoop(render, nil, renderLatency, renderLatency,
+		gloop.WithRenderAutoDisable(1), gloop.WithHysteresis(4, 2),
+		gloop.WithRenderSkipPolicy(gloop.RenderDropToLatest),
+		gloop.WithEventFilter(gloop.EventRenderDisabled))
+	assert.Nil(t, err)
+
+	assert.Nil(t, loop.Start())
+	<-loop.Events()
+	loop.Stop(nil)
+	<-loop.Done()
+
+	// The spike on frame 3 didn't count, because the hysteresis
+	// replaced the 1, and it took four slow frames from 10 on.
+	assert.Equal(t, 13, renders)
+	assert.True(t, loop.RenderDisabled())
+
+	_, err = gloop.NewLoop(nil, nil, renderLatency, renderLatency, gloop.WithHysteresis(0, 1))
+	assert.NotNil(t, err)
+	_, err = gloop.NewLoop(nil, nil, renderLatency, renderLatency, gloop.WithHysteresis(1, 0))
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,45 @@
+package gloop
+
+import (
+	"time"
+)
+
+// WithRenderDriven makes the render ticker the only thing driving the loop.
+// There is no separate simulate timer: at the top of each render frame,
+// the simulation catches up on the time since the last frame, and then
+// Render draws.
+// Catch-up is capped at as many steps as fit in five render frames,
+// unless WithMaxSimStepsPerFrame sets a cap of its own.
+func WithRenderDriven() Option {
+	return func(l *Loop) error {
+		l.renderDriven = true
+		return nil
+	}
+}
+
+// maxSimSteps is the most Simulate steps a single frame can run,
+// or 0 if there is no cap.
+func (r *runner) maxSimSteps() int {
+	l := r.l
+	if l.maxSimSteps > 0 || !l.renderDriven {
+		return l.maxSimSteps
+	}
+	perFrame := (l.RenderLatency + l.SimulationLatency - 1) / l.SimulationLatency
+	return maxRenderCatchUp * int(perFrame)
+}
+
+// simTimerC is the simulate timer's chan.
+// It is nil, and so never ready, when there is no simulate timer.
+func (r *runner) simTimerC() <-chan time.Time {
+	if r.simTimer == nil {
+		return nil
+	}
+	return r.simTimer.C
+}
+
+// resetSimTimer schedules the next simulate frame, if there is a simulate timer.
+func (r *runner) resetSimTimer(d time.Duration) {
+	if r.simTimer != nil {
+		r.simTimer.Reset(d)
+	}
+}
@@ -0,0 +1,59 @@
+package gloop_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/erinpentecost/gloop"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderDriven(t *testing.T) {
+	const renderLatency = 10 * time.Millisecond
+	const simLatency = renderLatency / 4
+	loop, err := gloop.NewLoop(nil, nil, renderLatency, simLatency, gloop.WithRenderDriven())
+	assert.Nil(t, err)
+	assert.NotNil(t, loop)
+
+	// Each entry is how many simulate steps ran before a render.
+	var bursts []int
+	steps := 0
+	var lastSim time.Time
+	loop.Simulate = func(step time.Duration) error {
+		assert.Equal(t, simLatency, step)
+		steps++
+		lastSim = time.Now()
+		return nil
+	}
+	loop.Render = func(step time.Duration) error {
+		if steps > 0 {
+			// The simulation caught up right before this render.
+			assert.True(t, time.Since(lastSim) < simLatency)
+		}
+		bursts = append(bursts, steps)
+		steps = 0
+		if len(bursts) == 3 {
+			// Stall long enough to need 40 steps.
+			time.Sleep(10 * renderLatency)
+		}
+		if len(bursts) == 12 {
+			loop.Stop(nil)
+		}
+		return nil
+	}
+	err = loop.Start()
+	assert.Nil(t, err)
+	<-loop.Done()
+	assert.Nil(t, loop.Err())
+
+	biggest := 0
+	for _, burst := range bursts {
+		// At most five render frames' worth of steps.
+		assert.True(t, burst <= 20)
+		if burst > biggest {
+			biggest = burst
+		}
+	}
+	// The stall was caught up on, but only up to the cap.
+	assert.Equal(t, 20, biggest)
+}
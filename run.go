@@ -0,0 +1,45 @@
+package gloop
+
+import (
+	"sync"
+)
+
+// Run starts the loop and blocks until it is done. It returns Err().
+// Callbacks run on the loop's own goroutine, unless
+// WithCallbacksOnRunGoroutine is set.
+func (l *Loop) Run() error {
+	if !l.runOnCaller {
+		if err := l.Start(); err != nil {
+			return err
+		}
+		<-l.Done()
+		return l.Err()
+	}
+
+	l.mu.Lock()
+	err := l.begin()
+	l.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	var wg sync.WaitGroup
+	wg.Add(1)
+	newRunner(l).run(&wg)
+	return l.Err()
+}
+
+// WithCallbacksOnRunGoroutine makes Run schedule frames and call
+// Simulate and Render on the goroutine that called it, instead of on
+// one of its own. This is for code that has to touch thread-local
+// state, like a GPU context, from main. Pair it with
+// runtime.LockOSThread in an init function to keep main on the main
+// thread. Stop, Heartbeat and the other methods are still safe to call
+// from other goroutines. Start is unaffected, and still runs the loop
+// on its own goroutine. This can't be combined with
+// WithConcurrentRender.
+func WithCallbacksOnRunGoroutine() Option {
+	return func(l *Loop) error {
+		l.runOnCaller = true
+		return nil
+	}
+}
@@ -0,0 +1,73 @@
+package gloop_test
+
+import (
+	"bytes"
+	"runtime"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/erinpentecost/gloop"
+	"github.com/stretchr/testify/assert"
+)
+
+// currentGoroutine parses the calling goroutine's id out of its stack.
+func currentGoroutine() uint64 {
+	buf := make([]byte, 64)
+	buf = buf[:runtime.Stack(buf, false)]
+	fields := bytes.Fields(buf)
+	id, _ := strconv.ParseUint(string(fields[1]), 10, 64)
+	return id
+}
+
+func TestRun(t *testing.T) {
+	loop, err := gloop.NewLoop(nil, nil, gloop.Hz60Delay, gloop.Hz60Delay)
+	assert.Nil(t, err)
+	frames := 0
+	loop.Render = func(step time.Duration) error {
+		frames++
+		if frames == 3 {
+			loop.Stop(nil)
+		}
+		return nil
+	}
+	assert.Nil(t, loop.Run())
+	assert.Equal(t, 3, frames)
+	assert.NotNil(t, loop.Run())
+}
+
+func TestCallbacksOnRunGoroutine(t *testing.T) {
+	loop, err := gloop.NewLoop(nil, nil, gloop.Hz60Delay, gloop.Hz60Delay,
+		gloop.WithCallbacksOnRunGoroutine())
+	assert.Nil(t, err)
+
+	caller := currentGoroutine()
+	var simGoroutines, renderGoroutines []uint64
+	loop.Simulate = func(step time.Duration) error {
+		simGoroutines = append(simGoroutines, currentGoroutine())
+		return nil
+	}
+	loop.Render = func(step time.Duration) error {
+		renderGoroutines = append(renderGoroutines, currentGoroutine())
+		return nil
+	}
+
+	// Stop and Heartbeat still work from another goroutine.
+	go func() {
+		<-loop.Heartbeat()
+		loop.Stop(nil)
+	}()
+	assert.Nil(t, loop.Run())
+
+	assert.NotEmpty(t, simGoroutines)
+	assert.NotEmpty(t, renderGoroutines)
+	for _, id := range append(simGoroutines, renderGoroutines...) {
+		assert.Equal(t, caller, id)
+	}
+}
+
+func TestCallbacksOnRunGoroutineConcurrentRender(t *testing.T) {
+	_, err := gloop.NewLoop(nil, nil, gloop.Hz60Delay, gloop.Hz60Delay,
+		gloop.WithCallbacksOnRunGoroutine(), gloop.WithConcurrentRender())
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,606 @@
+package gloop
+
+import (
+	"context"
+	"runtime/pprof"
+	"sync"
+	"time"
+)
+
+// runner is the state owned by the loop goroutine.
+// With WithConcurrentRender, render frames run on a second goroutine,
+// and the fields they share with the loop goroutine are guarded by mu.
+type runner struct {
+	l  *Loop
+	mu sync.Mutex
+	// rendering tracks the render goroutine, if there is one.
+	rendering sync.WaitGroup
+	// watching tracks the stall detector goroutine, if there is one.
+	watching sync.WaitGroup
+
+	// simTimer has an internal limiter, and I need to make sure the
+	// delay isn't accidentally doubled.
+	// It is nil when the loop is render driven.
+	simTimer *time.Timer
+	// rendTick has no internal limiter, the Ticker controls
+	// the execution rate.
+	rendTick *time.Ticker
+	// heartTick publishes stats.
+	heartTick *time.Ticker
+
+	// Time tracking.
+	simAccumulator time.Duration
+	simLatency     latencyTracker
+	rendLatency    latencyTracker
+	previousSim    time.Time
+	previousRend   time.Time
+	previousBeat   time.Time
+	// simClock tells render how much simulation time is pending.
+	simClock simClock
+	// busy is the time spent in callbacks since the last heartbeat.
+	busy time.Duration
+	// caughtUp is set when a simulate frame ran more than one step,
+	// until the next render.
+	caughtUp bool
+	// lastSimStep is when the last Simulate step finished.
+	lastSimStep time.Time
+	// rateRemainder is how far a rational simulation rate
+	// has drifted from a whole nanosecond.
+	rateRemainder int64
+	// renderHealth decides when WithRenderAutoDisable turns Render off.
+	renderHealth hysteresis
+	// Service time averages for WithEWMA.
+	renderEWMA ewma
+	simEWMA    ewma
+	// simTicksSinceRender counts Simulate steps since the last render frame.
+	simTicksSinceRender int
+	// renderFrames counts render frames, which can each call Render
+	// more than once, for FrameReport.
+	renderFrames uint64
+	// simSeen and rendSeen are set after the first frame of each kind.
+	simSeen  bool
+	rendSeen bool
+	// simBase and rendBase are the last ResetBaseline each frame kind saw.
+	simBase  uint64
+	rendBase uint64
+	// simResume and rendResume are the last resume each frame kind saw.
+	simResume  uint64
+	rendResume uint64
+	// jitter perturbs frame times, for WithJitterInjection.
+	jitter *jitterSource
+	// lastDrawn is when the last frame was drawn, for WithIdleRender.
+	lastDrawn time.Time
+	// idle decides when WithIdleRender drops the render rate.
+	idle hysteresis
+	// rendEvery is the render ticker's period.
+	rendEvery time.Duration
+	// beatsDropped counts heartbeat samples thrown away since the last
+	// one was sent.
+	beatsDropped uint64
+	// beats counts heartbeat samples taken, for WithHeartbeatWarmupSkip.
+	beats int
+	// renderCalls and simCalls count callbacks, for WithAllocSampling.
+	renderCalls int
+	simCalls    int
+}
+
+func newRunner(l *Loop) *runner {
+	now := time.Now()
+	return &runner{
+		l:              l,
+		simAccumulator: time.Duration(0),
+		simLatency:     newLatencyTracker(),
+		rendLatency:    newLatencyTracker(),
+		previousSim:    now,
+		previousRend:   now,
+		previousBeat:   now,
+		simClock:       simClock{at: now, scale: 1},
+		renderHealth:   l.newHysteresis(l.renderStrikes, 0),
+		idle:           l.newHysteresis(1, 1),
+		jitter:         l.newJitter(),
+	}
+}
+
+// run is the body of the loop goroutine.
+// started is marked done once the loop is about to begin.
+func (r *runner) run(started *sync.WaitGroup) {
+	l := r.l
+	defer close(l.exited)
+	defer l.lockThread()()
+	l.trackGoroutine()
+
+	if !l.keepTimerResolution {
+		beginTimerPeriod()
+		defer endTimerPeriod()
+	}
+
+	if !l.renderDriven {
+		// The first simulate frame waits out any phase offset,
+		// and measures time from then.
+		r.previousSim = r.previousSim.Add(l.simPhase)
+		r.simTimer = time.NewTimer(l.simPhase)
+		defer r.simTimer.Stop()
+	}
+	r.rendEvery = l.RenderLatency
+	r.rendTick = time.NewTicker(r.rendEvery)
+	r.heartTick = time.NewTicker(time.Second)
+	defer r.rendTick.Stop()
+	defer r.heartTick.Stop()
+	// Done() has always closed by the time this runs.
+	defer l.closeChannels()
+	// Wait for the stall detector before its channel is closed.
+	defer r.watching.Wait()
+	// finish has already run unless a callback panicked.
+	defer r.finish()
+	defer func() {
+		l.emit(Event{Kind: EventStopped, Time: time.Now(), Err: l.Err()})
+	}()
+	defer l.Stop(nil)
+
+	l.emit(Event{Kind: EventStarted, Time: r.previousRend})
+	started.Done()
+	l.setDeadlines(r.previousSim, r.previousRend.Add(l.RenderLatency))
+
+	if l.concurrentRender {
+		r.rendering.Add(1)
+		go r.renderLoop()
+	}
+	if l.stallWindow > 0 {
+		l.markTick(r.previousSim)
+		r.watching.Add(1)
+		go r.watchStalls()
+	}
+
+	for {
+		// Don't start another frame if Stop was called during the last one.
+		if l.stopping() {
+			r.finish()
+			return
+		}
+
+		// Go picks at random between ready cases, so check
+		// frames in priority order before waiting on anything.
+		// Heartbeats and forced ticks go first, or a loop that
+		// always has a frame due would never get to them.
+		r.pollHousekeeping()
+		if r.pollFrame() {
+			continue
+		}
+
+		select {
+		case <-l.doneSignal:
+			return
+		case <-l.done:
+			r.finish()
+			return
+		case <-r.heartTick.C:
+			r.beat()
+		case <-r.simTimerC():
+			r.simulateFrame(time.Now())
+		case tick, ok := <-r.rendTickC():
+			r.renderTick(tick, ok)
+		case reply := <-l.forced:
+			reply <- r.forceTick()
+		case reply := <-l.advance:
+			reply <- r.advanceFrame()
+		}
+	}
+}
+
+// pollFrame runs a simulate or render frame if one is due,
+// without blocking. Simulate goes first unless WithRenderPriority was set.
+// It returns false if neither was due.
+func (r *runner) pollFrame() bool {
+	if r.l.renderFirst {
+		return r.pollRender() || r.pollSimulate()
+	}
+	return r.pollSimulate() || r.pollRender()
+}
+
+// pollHousekeeping switches to any preset from SetPreset, publishes a
+// heartbeat, and runs a forced tick or an advanced frame, if any are
+// waiting, without blocking.
+func (r *runner) pollHousekeeping() {
+	r.applyPreset()
+	select {
+	case <-r.heartTick.C:
+		r.beat()
+	default:
+	}
+	select {
+	case reply := <-r.l.forced:
+		reply <- r.forceTick()
+	case reply := <-r.l.advance:
+		reply <- r.advanceFrame()
+	default:
+	}
+}
+
+func (r *runner) pollSimulate() bool {
+	select {
+	case <-r.simTimerC():
+		r.simulateFrame(time.Now())
+		return true
+	default:
+		return false
+	}
+}
+
+func (r *runner) pollRender() bool {
+	select {
+	case tick, ok := <-r.rendTickC():
+		r.renderTick(tick, ok)
+		return true
+	default:
+		return false
+	}
+}
+
+// beat publishes a heartbeat sample.
+func (r *runner) beat() {
+	l := r.l
+	curTime := time.Now()
+	r.mu.Lock()
+	sample := LatencySample{
+		RenderLatency:   r.rendLatency.Latency(),
+		SimulateLatency: r.simLatency.Latency(),
+		Utilization:     float64(r.busy) / float64(curTime.Sub(r.previousBeat)),
+
+		RenderServiceEWMA:   r.renderEWMA.duration(),
+		SimulateServiceEWMA: r.simEWMA.duration(),
+
+		Dropped: r.beatsDropped,
+	}
+	r.busy = 0
+	r.mu.Unlock()
+	r.previousBeat = curTime
+	r.beats++
+	if r.beats <= l.warmupBeats {
+		return
+	}
+
+	l.mu.Lock()
+	l.lastBeat = sample
+	l.hasBeat = true
+	l.mu.Unlock()
+
+	l.notifyObservers(sample)
+	select {
+	case l.heartbeat <- sample:
+		r.beatsDropped = 0
+	default: // Throw it away if no one is listening.
+		r.beatsDropped++
+	}
+	l.emit(Event{Kind: EventHeartbeat, Time: curTime, Sample: sample})
+}
+
+// simulateFrame runs as many fixed Simulate steps as have built up by curTime.
+func (r *runner) simulateFrame(curTime time.Time) {
+	l := r.l
+	l.markTick(time.Now())
+	r.rebaseSimulation(curTime)
+	r.rebaseResumeSimulation(curTime)
+	// How much are we behind?
+	frameTime := r.jitter.perturb(r.firstFrameTime(curTime.Sub(r.previousSim), &r.simSeen))
+	r.previousSim = curTime
+	if l.SimulationPaused() || l.halted() {
+		// Time doesn't build up while paused.
+		r.simLatency.MarkDone(frameTime)
+		r.publishSimClock(0)
+		r.resetSimTimer(l.SimulationLatency)
+		l.setDeadlines(curTime.Add(l.SimulationLatency), time.Time{})
+		return
+	}
+	// Simulation time passes at the time scale.
+	scale := l.timeScaleAt(curTime)
+	scaled := time.Duration(float64(frameTime) * scale)
+	r.simLatency.MarkDone(frameTime - scaled)
+	r.takeAccumulator()
+	if l.variableSim {
+		// There are no fixed steps, so nothing builds up.
+		r.simulateElapsed(curTime, scaled)
+		r.simAccumulator, scaled = 0, 0
+	}
+	r.simAccumulator += scaled
+	// Call simulate() if we built up enough lag.
+	steps := 0
+	maxSteps := r.maxSimSteps()
+	r.simulateBatch(curTime, maxSteps)
+	for step := r.simStep(); r.simAccumulator >= step && !l.stopping(); step = r.simStep() {
+		// Run the simulation with a fixed step.
+		start := time.Now()
+		if er := r.simulate(step); er != nil {
+			wrapped := wrapLoopError(er, TokenSimulate, "Error returned by Simulate(%s)", step.String())
+			wrapped.Misc["curTime"] = curTime
+			l.reportError(wrapped)
+			l.Stop(wrapped)
+			break
+		}
+		r.serviced(TokenSimulate, start, step, step)
+
+		r.simLatency.MarkDone(step)
+
+		// Keep track of leftover time.
+		r.simAccumulator -= step
+		r.advanceSimStep()
+		steps++
+
+		if l.noCatchUp {
+			// Throw away the backlog instead of catching up.
+			r.dropSimulation(r.simAccumulator)
+		} else if maxSteps > 0 && steps >= maxSteps {
+			// Throw away whole steps past the budget, but keep the remainder.
+			r.dropSimulation(r.simAccumulator - r.simAccumulator%l.SimulationLatency)
+		}
+	}
+	if steps > 1 {
+		r.mu.Lock()
+		r.caughtUp = true
+		r.mu.Unlock()
+	}
+	r.publishSimClock(scale)
+	r.publishInterpolation()
+	l.runCommits()
+	// Set up next call to simulate()...
+	// Never wait longer than SimulationLatency, so a time scale
+	// that goes up while we wait is noticed in time.
+	next := l.SimulationLatency
+	if scale > 0 {
+		next = time.Duration(float64(l.SimulationLatency-r.simAccumulator) / scale)
+		if next > l.SimulationLatency {
+			next = l.SimulationLatency
+		}
+	}
+	r.resetSimTimer(next)
+	l.setDeadlines(time.Now().Add(next), time.Time{})
+}
+
+// dropSimulation throws away d of built-up simulation time.
+// The dropped time is never simulated.
+func (r *runner) dropSimulation(d time.Duration) {
+	r.l.updateStats(func(stats *LoopStats) {
+		stats.TotalDroppedSimTicks += uint64(d / r.l.SimulationLatency)
+	})
+	r.simLatency.MarkDone(d)
+	r.simAccumulator -= d
+}
+
+// renderFrame calls Render and then each render layer.
+// tick is when the render ticker fired.
+func (r *runner) renderFrame(tick time.Time) {
+	l := r.l
+	l.markTick(time.Now())
+	if l.renderDriven {
+		// Catch the simulation up before drawing.
+		r.simulateFrame(r.tickTime(tick))
+		if l.stopping() {
+			return
+		}
+	}
+	if l.RenderDisabled() || l.halted() {
+		r.skipRenderFrame()
+		return
+	}
+	if r.idleSkip() {
+		r.skipRenderFrame()
+		return
+	}
+	latency := r.applyRenderLimit()
+	// Commits wait until the frame is drawn.
+	l.frameMu.Lock()
+	defer l.frameMu.Unlock()
+	// How much are we behind?
+	curTime := r.tickTime(tick)
+	r.rebaseRender(curTime)
+	r.rebaseResumeRender(curTime)
+	frameTime := r.jitter.perturb(r.firstFrameTime(curTime.Sub(r.previousRend), &r.rendSeen))
+	r.previousRend = curTime
+	deadline := tick.Add(latency)
+	l.setDeadlines(time.Time{}, deadline)
+	r.mu.Lock()
+	clock, caughtUp := r.simClock, r.caughtUp
+	simTicks := r.simTicksSinceRender
+	r.caughtUp = false
+	r.simTicksSinceRender = 0
+	r.mu.Unlock()
+	alpha := l.Alpha()
+	if !l.SimulationPaused() {
+		alpha = l.setAlpha(clock.pending(curTime), l.SimulationLatency)
+	}
+	l.setSettled(caughtUp)
+	l.hint(PresentHint{Present: deadline, Alpha: alpha})
+
+	// Unlike simulate(), we can skip calls by varying the input time delta.
+	frames, step := 1, frameTime
+	switch l.renderSkip {
+	case RenderDropOldest:
+		step = latency
+	case RenderNeverDrop:
+		step = latency
+		frames = int(frameTime / latency)
+		if frames < 1 {
+			frames = 1
+		} else if frames > maxRenderCatchUp {
+			frames = maxRenderCatchUp
+		}
+	}
+	if l.maxRenderStep > 0 && step > l.maxRenderStep {
+		step = l.maxRenderStep
+	}
+	if missed := int(frameTime/latency) - frames; missed > 0 {
+		// The ticker drops ticks when we fall behind.
+		l.updateStats(func(stats *LoopStats) {
+			stats.TotalDroppedRenderFrames += uint64(missed)
+		})
+	}
+
+	for i := 0; i < frames && !l.stopping(); i++ {
+		if er := r.renderStep(step, deadline); er != nil {
+			if l.renderStrikes == 0 {
+				l.Stop(er)
+				return
+			}
+			r.renderStrike(true, er)
+			break
+		}
+	}
+
+	r.mu.Lock()
+	r.rendLatency.MarkDone(frameTime)
+	r.mu.Unlock()
+
+	r.renderFrames++
+	if l.frameReport != nil && !l.stopping() {
+		l.frameReport(FrameReport{
+			Frame:    r.renderFrames,
+			Alpha:    alpha,
+			SimTicks: simTicks,
+			Elapsed:  frameTime,
+			CatchUp:  caughtUp,
+		})
+	}
+}
+
+// renderStep calls Render and then each render layer once.
+// Any returned error is already wrapped.
+func (r *runner) renderStep(step time.Duration, deadline time.Time) error {
+	l := r.l
+	start := time.Now()
+	if er := r.render(step, deadline); er != nil {
+		wrapped := wrapLoopError(er, TokenRender, "Error returned by Render(%s)", step.String())
+		wrapped.Misc["curTime"] = start
+		l.reportError(wrapped)
+		return wrapped
+	}
+	if er := r.renderLayers(step, start); er != nil {
+		return er
+	}
+	r.serviced(TokenRender, start, step, l.RenderLatency)
+	return nil
+}
+
+// serviced records that a callback which was handed step
+// started at start and should have finished within budget.
+func (r *runner) serviced(source TokenSource, start time.Time, step, budget time.Duration) {
+	l := r.l
+	now := time.Now()
+	service := now.Sub(start)
+
+	overrun := service > budget
+	spiked := float64(service) > l.spikeThreshold*float64(budget)
+
+	if source == TokenSimulate {
+		r.lastSimStep = now
+		r.mu.Lock()
+		r.simTicksSinceRender++
+		r.mu.Unlock()
+	}
+	if l.ewmaAlpha > 0 {
+		r.mu.Lock()
+		if source == TokenRender {
+			r.renderEWMA.add(service, l.ewmaAlpha)
+		} else {
+			r.simEWMA.add(service, l.ewmaAlpha)
+		}
+		r.mu.Unlock()
+	}
+
+	kind := EventSimulate
+	var frame uint64
+	var ready bool
+	l.updateStats(func(stats *LoopStats) {
+		if source == TokenRender {
+			kind = EventRender
+			stats.RenderFrames++
+			frame = stats.RenderFrames
+			l.renderSketch.add(service)
+		} else {
+			stats.SimTicks++
+			frame = stats.SimTicks
+			l.simSketch.add(service)
+		}
+		if overrun {
+			stats.TotalOverruns++
+		}
+		l.health.push(overrun)
+		if l.traceLimit > 0 {
+			l.trace.push(l.traceLimit, newTraceEvent(source, start, service, step))
+		}
+		if spiked {
+			stats.TotalSpikes++
+		}
+		ready = stats.SimTicks > 0 && stats.RenderFrames > 0
+	})
+	if ready {
+		l.markReady()
+	}
+	if source == TokenRender {
+		l.recordFrameTime(service)
+		if l.renderStrikes > 0 {
+			r.renderStrike(overrun, nil)
+		}
+	}
+
+	l.emit(Event{Kind: kind, Time: now, Source: source, Step: step, Service: service, Budget: budget})
+	if spiked {
+		l.spike(SpikeEvent{Source: source, Actual: service, Budget: budget, Frame: frame})
+	}
+	if overrun {
+		l.emit(Event{Kind: EventOverrun, Time: now, Source: source, Step: step, Service: service, Budget: budget})
+	}
+}
+
+// invoke calls fn with the goroutine labels in ctx set, inside a trace
+// region if tracing is on, and counts the time spent towards utilization.
+func (r *runner) invoke(ctx context.Context, region string, step time.Duration, fn func() error) error {
+	start := time.Now()
+	pprof.SetGoroutineLabels(ctx)
+	leaveGuard := r.l.enterGuard(region)
+	leaveBudget := r.enterBudget(region, start)
+	defer func() {
+		leaveBudget()
+		leaveGuard()
+		pprof.SetGoroutineLabels(unlabeled)
+		r.mu.Lock()
+		r.busy += time.Since(start)
+		r.mu.Unlock()
+	}()
+	return traced(ctx, region, step, r.sampleAllocs(region, r.l.recovering(region, fn)))
+}
+
+// simulate invokes Simulate, surrounded by any sim hooks.
+func (r *runner) simulate(step time.Duration) error {
+	l := r.l
+	var tick uint64
+	if l.beforeSim != nil || l.afterSim != nil {
+		tick = l.Stats().SimTicks + 1
+	}
+	if l.beforeSim != nil {
+		l.beforeSim(tick)
+	}
+	err := r.invoke(simulateLabels, simulateRegion, step, func() error {
+		if l.Simulate != nil {
+			return l.simulateSubSteps(step)
+		}
+		return nil
+	})
+	if l.afterSim != nil {
+		l.afterSim(tick)
+	}
+	return err
+}
+
+// render invokes whichever render function is configured.
+// deadline is when the next render tick is due.
+func (r *runner) render(step time.Duration, deadline time.Time) error {
+	l := r.l
+	return r.invoke(renderLabels, renderRegion, step, func() error {
+		if l.RenderWithDeadline != nil {
+			return l.RenderWithDeadline(step, deadline)
+		}
+		if l.Render != nil {
+			return l.Render(step)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,35 @@
+package gloop
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// RunWithSignals starts the loop and blocks until it is done.
+// If one of sigs arrives first, the loop is stopped without an error.
+// With no sigs, it listens for SIGINT and SIGTERM.
+// Signal handling goes back to what it was before once this returns.
+// It returns Err().
+func (l *Loop) RunWithSignals(sigs ...os.Signal) error {
+	if len(sigs) == 0 {
+		sigs = []os.Signal{os.Interrupt, syscall.SIGTERM}
+	}
+
+	// Listen before starting so a signal can't slip past us.
+	caught := make(chan os.Signal, 1)
+	signal.Notify(caught, sigs...)
+	defer signal.Stop(caught)
+
+	if err := l.Start(); err != nil {
+		return err
+	}
+
+	select {
+	case <-caught:
+		l.Stop(nil)
+	case <-l.Done():
+	}
+	<-l.Done()
+	return l.Err()
+}
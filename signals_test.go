@@ -0,0 +1,56 @@
+package gloop_test
+
+import (
+	"os"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/erinpentecost/gloop"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunWithSignals(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("can't send os.Interrupt to ourselves on windows")
+	}
+
+	running := make(chan struct{})
+	var once sync.Once
+	render := func(step time.Duration) error {
+		once.Do(func() { close(running) })
+		return nil
+	}
+	simulate := func(step time.Duration) error { return nil }
+
+	loop, err := gloop.NewLoop(render, simulate, gloop.Hz60Delay, gloop.Hz60Delay)
+	assert.NoError(t, err)
+
+	go func() {
+		<-running
+		self, err := os.FindProcess(os.Getpid())
+		assert.NoError(t, err)
+		assert.NoError(t, self.Signal(os.Interrupt))
+	}()
+
+	result := make(chan error, 1)
+	go func() { result <- loop.RunWithSignals(os.Interrupt) }()
+
+	select {
+	case err := <-result:
+		assert.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("loop didn't stop on os.Interrupt")
+	}
+	<-loop.Done()
+}
+
+func TestRunWithSignalsError(t *testing.T) {
+	render := func(step time.Duration) error { return nil }
+	simulate := func(step time.Duration) error { return assert.AnError }
+
+	loop, err := gloop.NewLoop(render, simulate, gloop.Hz60Delay, gloop.Hz60Delay)
+	assert.NoError(t, err)
+	assert.Error(t, loop.RunWithSignals())
+}
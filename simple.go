@@ -0,0 +1,20 @@
+package gloop
+
+import (
+	"time"
+)
+
+// NewSimpleLoop creates a minimal fixed-step loop that calls tick
+// every latency, for programs that update and draw in one place.
+// tick is Simulate, so it is always handed latency as its step, and
+// falls behind by catching up rather than stretching the step. There
+// is no Render, and no separate render schedule: the loop is render
+// driven, off a single ticker.
+// The loop is not started.
+func NewSimpleLoop(tick LoopFn, latency time.Duration, opts ...Option) (*Loop, error) {
+	if tick == nil {
+		return nil, wrapLoopError(nil, TokenLoop, "NewSimpleLoop needs tick")
+	}
+	opts = append([]Option{WithRenderDriven()}, opts...)
+	return NewLoop(nil, tick, latency, latency, opts...)
+}
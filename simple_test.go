@@ -0,0 +1,41 @@
+package gloop_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/erinpentecost/gloop"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewSimpleLoop(t *testing.T) {
+	const latency = 10 * time.Millisecond
+	var steps []time.Duration
+	var loop *gloop.Loop
+	tick := func(step time.Duration) error {
+		steps = append(steps, step)
+		if len(steps) == 20 {
+			loop.Stop(nil)
+		}
+		return nil
+	}
+	loop, err := gloop.NewSimpleLoop(tick, latency)
+	assert.Nil(t, err)
+	assert.Nil(t, loop.Render)
+
+	start := time.Now()
+	assert.Nil(t, loop.Start())
+	<-loop.Done()
+	elapsed := time.Since(start)
+
+	assert.Len(t, steps, 20)
+	for _, step := range steps {
+		assert.Equal(t, latency, step)
+	}
+	// 20 ticks at 100 Hz take about 200ms.
+	assert.True(t, elapsed >= 180*time.Millisecond, "elapsed %s", elapsed)
+	assert.True(t, elapsed < time.Second, "elapsed %s", elapsed)
+
+	_, err = gloop.NewSimpleLoop(nil, latency)
+	assert.NotNil(t, err)
+}
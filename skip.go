@@ -0,0 +1,51 @@
+package gloop
+
+import (
+	"time"
+)
+
+// maxRenderCatchUp is the most renders RenderNeverDrop will run
+// for a single tick, so a slow Render can't spiral.
+const maxRenderCatchUp = 5
+
+// RenderSkipPolicy controls what happens when Render falls behind.
+type RenderSkipPolicy int
+
+const (
+	// RenderDropToLatest renders once with a step covering all the
+	// time since the last render. This is the default.
+	RenderDropToLatest RenderSkipPolicy = iota
+	// RenderDropOldest renders once with the usual RenderLatency step.
+	// The missed time is thrown away.
+	RenderDropOldest RenderSkipPolicy = iota
+	// RenderNeverDrop renders once per missed tick, each with the usual
+	// RenderLatency step. To avoid a spiral of death, at most
+	// five renders run back to back; anything past that is dropped.
+	RenderNeverDrop RenderSkipPolicy = iota
+)
+
+// WithRenderSkipPolicy sets what happens when Render falls behind.
+func WithRenderSkipPolicy(policy RenderSkipPolicy) Option {
+	return func(l *Loop) error {
+		switch policy {
+		case RenderDropToLatest, RenderDropOldest, RenderNeverDrop:
+			l.renderSkip = policy
+			return nil
+		default:
+			return wrapLoopError(nil, TokenLoop, "Unknown RenderSkipPolicy %d", policy)
+		}
+	}
+}
+
+// WithMaxRenderStep caps the step handed to Render, so a long stall
+// doesn't hand camera smoothing an absurd delta.
+// Real time still advances as usual; only the step Render sees is clamped.
+func WithMaxRenderStep(d time.Duration) Option {
+	return func(l *Loop) error {
+		if d <= 0 {
+			return wrapLoopError(nil, TokenLoop, "MaxRenderStep can't be lte 0")
+		}
+		l.maxRenderStep = d
+		return nil
+	}
+}
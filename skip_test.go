@@ -0,0 +1,109 @@
+package gloop_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/erinpentecost/gloop"
+	"github.com/stretchr/testify/assert"
+)
+
+type renderCall struct {
+	at   time.Time
+	step time.Duration
+}
+
+// stalledRenders stalls the third render for several ticks
+// and returns the render calls that follow it.
+func stalledRenders(t *testing.T, policy gloop.RenderSkipPolicy) []renderCall {
+	simulate := func(step time.Duration) error {
+		return nil
+	}
+	loop, err := gloop.NewLoop(nil, simulate, gloop.Hz60Delay, gloop.Hz60Delay, gloop.WithRenderSkipPolicy(policy))
+	assert.Nil(t, err)
+	assert.NotNil(t, loop)
+	var calls []renderCall
+	loop.Render = func(step time.Duration) error {
+		calls = append(calls, renderCall{at: time.Now(), step: step})
+		if len(calls) == 3 {
+			time.Sleep(4*gloop.Hz60Delay + gloop.Hz60Delay/2)
+		}
+		if len(calls) == 12 {
+			loop.Stop(nil)
+		}
+		return nil
+	}
+	err = loop.Start()
+	assert.Nil(t, err)
+	<-loop.Done()
+	assert.Nil(t, loop.Err())
+	return calls[3:]
+}
+
+// burst counts how many calls ran back to back.
+func burst(calls []renderCall) int {
+	n := 1
+	for n < len(calls) && calls[n].at.Sub(calls[n-1].at) < gloop.Hz60Delay/4 {
+		n++
+	}
+	return n
+}
+
+func TestRenderDropToLatest(t *testing.T) {
+	calls := stalledRenders(t, gloop.RenderDropToLatest)
+	assert.Equal(t, 1, burst(calls))
+	assert.True(t, calls[0].step >= 4*gloop.Hz60Delay)
+}
+
+func TestRenderDropOldest(t *testing.T) {
+	calls := stalledRenders(t, gloop.RenderDropOldest)
+	assert.Equal(t, 1, burst(calls))
+	assert.Equal(t, gloop.Hz60Delay, calls[0].step)
+}
+
+func TestRenderNeverDrop(t *testing.T) {
+	calls := stalledRenders(t, gloop.RenderNeverDrop)
+	// The stall covers four and a half ticks. A slow wakeup
+	// afterwards can push that to a fifth.
+	n := burst(calls)
+	assert.True(t, n == 4 || n == 5, "burst: %d", n)
+	for _, call := range calls {
+		assert.Equal(t, gloop.Hz60Delay, call.step)
+	}
+}
+
+func TestRenderSkipPolicyError(t *testing.T) {
+	loop, err := gloop.NewLoop(nil, nil, gloop.Hz60Delay, gloop.Hz60Delay, gloop.WithRenderSkipPolicy(gloop.RenderSkipPolicy(42)))
+	assert.NotNil(t, err)
+	assert.Nil(t, loop)
+}
+
+func TestMaxRenderStep(t *testing.T) {
+	simulate := func(step time.Duration) error {
+		return nil
+	}
+	loop, err := gloop.NewLoop(nil, simulate, gloop.Hz60Delay, gloop.Hz60Delay, gloop.WithMaxRenderStep(2*gloop.Hz60Delay))
+	assert.Nil(t, err)
+	assert.NotNil(t, loop)
+	var steps []time.Duration
+	loop.Render = func(step time.Duration) error {
+		steps = append(steps, step)
+		if len(steps) == 3 {
+			time.Sleep(5 * gloop.Hz60Delay)
+		}
+		if len(steps) == 6 {
+			loop.Stop(nil)
+		}
+		return nil
+	}
+	err = loop.Start()
+	assert.Nil(t, err)
+	<-loop.Done()
+	assert.Nil(t, loop.Err())
+
+	// The frame after the stall is clamped.
+	assert.Equal(t, 2*gloop.Hz60Delay, steps[3])
+	for _, step := range steps {
+		assert.True(t, step <= 2*gloop.Hz60Delay)
+	}
+}
@@ -0,0 +1,49 @@
+package gloop
+
+import (
+	"time"
+)
+
+// defaultSpikeThreshold is how many times over budget a single
+// frame has to be before it counts as a spike.
+const defaultSpikeThreshold = 3.0
+
+// SpikeEvent reports a single frame that took far longer than its budget.
+type SpikeEvent struct {
+	Source TokenSource
+	// Actual is how long the callback took.
+	Actual time.Duration
+	// Budget is how long the callback was supposed to take.
+	Budget time.Duration
+	// Frame counts calls to the callback, starting at 1.
+	Frame uint64
+}
+
+// WithSpikeThreshold sets how many times over its budget a frame
+// has to take before it is reported on Spikes(). The default is 3.
+func WithSpikeThreshold(multiple float64) Option {
+	return func(l *Loop) error {
+		if multiple <= 0 {
+			return wrapLoopError(nil, TokenLoop, "Spike threshold can't be lte 0")
+		}
+		l.spikeThreshold = multiple
+		return nil
+	}
+}
+
+// Spikes returns a channel that reports each frame that blew past
+// its budget. This complements the averages in Heartbeat().
+// If the channel fills up because no one is reading, new spikes are
+// thrown away. The channel is closed when the loop stops.
+func (l *Loop) Spikes() <-chan SpikeEvent {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.spikes
+}
+
+func (l *Loop) spike(s SpikeEvent) {
+	select {
+	case l.spikes <- s:
+	default: // Throw it away if no one is listening.
+	}
+}
@@ -0,0 +1,49 @@
+package gloop_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/erinpentecost/gloop"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSpikes(t *testing.T) {
+	simulate := func(step time.Duration) error {
+		return nil
+	}
+	loop, err := gloop.NewLoop(nil, simulate, gloop.Hz60Delay, gloop.Hz60Delay)
+	assert.Nil(t, err)
+	assert.NotNil(t, loop)
+	renders := 0
+	loop.Render = func(step time.Duration) error {
+		renders++
+		if renders == 3 {
+			time.Sleep(4 * gloop.Hz60Delay)
+		}
+		if renders == 6 {
+			loop.Stop(nil)
+		}
+		return nil
+	}
+	err = loop.Start()
+	assert.Nil(t, err)
+
+	var spikes []gloop.SpikeEvent
+	for s := range loop.Spikes() {
+		spikes = append(spikes, s)
+	}
+	assert.Nil(t, loop.Err())
+
+	assert.Equal(t, 1, len(spikes))
+	assert.Equal(t, gloop.TokenRender, spikes[0].Source)
+	assert.Equal(t, uint64(3), spikes[0].Frame)
+	assert.Equal(t, gloop.Hz60Delay, spikes[0].Budget)
+	assert.True(t, spikes[0].Actual >= 4*gloop.Hz60Delay)
+}
+
+func TestSpikeThresholdError(t *testing.T) {
+	loop, err := gloop.NewLoop(nil, nil, gloop.Hz60Delay, gloop.Hz60Delay, gloop.WithSpikeThreshold(0))
+	assert.NotNil(t, err)
+	assert.Nil(t, loop)
+}
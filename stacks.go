@@ -0,0 +1,84 @@
+package gloop
+
+import (
+	"bytes"
+	"fmt"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// goid returns the id of the calling goroutine.
+// The runtime doesn't export it, so it's parsed out of the
+// "goroutine N [running]:" header of a stack trace.
+func goid() uint64 {
+	buf := make([]byte, 64)
+	buf = buf[:runtime.Stack(buf, false)]
+	buf = bytes.TrimPrefix(buf, []byte("goroutine "))
+	if i := bytes.IndexByte(buf, ' '); i >= 0 {
+		buf = buf[:i]
+	}
+	id, _ := strconv.ParseUint(string(buf), 10, 64)
+	return id
+}
+
+// goroutineSet is the loop's goroutines, for DumpStacks.
+// It has its own lock because the loop goroutine joins it
+// while Start is still holding the loop's.
+type goroutineSet struct {
+	mu  sync.Mutex
+	ids []uint64
+}
+
+// trackGoroutine remembers the calling goroutine for DumpStacks.
+func (l *Loop) trackGoroutine() {
+	id := goid()
+	l.goids.mu.Lock()
+	defer l.goids.mu.Unlock()
+	l.goids.ids = append(l.goids.ids, id)
+}
+
+// DumpStacks describes what the loop is doing right now, for when it
+// seems to be wedged. It has the stack of the loop goroutine (and the
+// render goroutine, with WithConcurrentRender), followed by the
+// recent frame times from RecentFrameTimes.
+// Stacks are only included while those goroutines are running.
+func (l *Loop) DumpStacks() string {
+	l.goids.mu.Lock()
+	ids := append([]uint64(nil), l.goids.ids...)
+	l.goids.mu.Unlock()
+	frames := l.RecentFrameTimes()
+
+	// Grow the buffer until every goroutine fits.
+	buf := make([]byte, 1<<16)
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) {
+			buf = buf[:n]
+			break
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+
+	var sb strings.Builder
+	found := 0
+	for _, stack := range strings.Split(string(buf), "\n\n") {
+		for _, id := range ids {
+			if strings.HasPrefix(stack, fmt.Sprintf("goroutine %d [", id)) {
+				sb.WriteString(strings.TrimSpace(stack))
+				sb.WriteString("\n\n")
+				found++
+			}
+		}
+	}
+	if found == 0 {
+		sb.WriteString("loop goroutine is not running\n\n")
+	}
+
+	fmt.Fprintf(&sb, "recent frame times (%d, oldest first):\n", len(frames))
+	for _, d := range frames {
+		fmt.Fprintf(&sb, "%v\n", d)
+	}
+	return sb.String()
+}
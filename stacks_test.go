@@ -0,0 +1,43 @@
+package gloop_test
+
+import (
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/erinpentecost/gloop"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDumpStacks(t *testing.T) {
+	var frames int32
+	wedged := make(chan struct{})
+	release := make(chan struct{})
+	render := func(step time.Duration) error {
+		// Wedge on the fifth frame.
+		if atomic.AddInt32(&frames, 1) == 5 {
+			close(wedged)
+			<-release
+		}
+		return nil
+	}
+	simulate := func(step time.Duration) error { return nil }
+	loop, err := gloop.NewLoop(render, simulate, time.Millisecond, time.Millisecond)
+	assert.Nil(t, err)
+
+	assert.Contains(t, loop.DumpStacks(), "not running")
+
+	assert.Nil(t, loop.Start())
+	<-wedged
+
+	dump := loop.DumpStacks()
+	assert.Contains(t, dump, "goroutine ")
+	assert.Contains(t, dump, "renderFrame")
+	assert.Contains(t, dump, "recent frame times (4, oldest first)")
+	assert.Equal(t, 4, strings.Count(dump[strings.Index(dump, "recent frame times"):], "\n")-1)
+
+	close(release)
+	loop.Stop(nil)
+	<-loop.Done()
+}
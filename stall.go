@@ -0,0 +1,89 @@
+package gloop
+
+import (
+	"time"
+)
+
+// StallEvent reports that the loop went a whole window without
+// starting a simulate or render frame.
+type StallEvent struct {
+	// Last is when the last frame started.
+	Last time.Time
+	// Detected is when the stall was noticed.
+	Detected time.Time
+	// Window is the window passed to WithStallDetector.
+	Window time.Duration
+}
+
+// WithStallDetector watches the loop from a goroutine of its own, and
+// reports on Stalls() if neither a simulate nor a render frame starts
+// for window. That happens when the loop goroutine is starved of CPU,
+// or a callback never returns. Each stall is reported once, when it
+// is noticed, which is up to a quarter of window late.
+// If stop is true, the loop is also stopped with a LoopError.
+// Frames still count while the simulation is paused or the loop is
+// suspended, so those aren't stalls.
+func WithStallDetector(window time.Duration, stop bool) Option {
+	return func(l *Loop) error {
+		if window <= 0 {
+			return wrapLoopError(nil, TokenLoop, "StallDetector window can't be lte 0")
+		}
+		l.stallWindow = window
+		l.stallStop = stop
+		return nil
+	}
+}
+
+// Stalls returns a channel that reports each stall found by
+// WithStallDetector. If the channel fills up because no one is
+// reading, new stalls are thrown away. The channel is closed
+// when the loop stops.
+func (l *Loop) Stalls() <-chan StallEvent {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.stalls
+}
+
+// markTick records that a frame started, for the stall detector.
+func (l *Loop) markTick(now time.Time) {
+	if l.stallWindow == 0 {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.lastTick = now
+}
+
+// watchStalls is the body of the stall detector goroutine.
+func (r *runner) watchStalls() {
+	l := r.l
+	defer r.watching.Done()
+	check := time.NewTicker(l.stallWindow / 4)
+	defer check.Stop()
+	stalled := false
+	for {
+		select {
+		case <-l.done:
+			return
+		case now := <-check.C:
+			l.mu.Lock()
+			last := l.lastTick
+			l.mu.Unlock()
+			if now.Sub(last) < l.stallWindow {
+				stalled = false
+				continue
+			}
+			if stalled {
+				continue
+			}
+			stalled = true
+			select {
+			case l.stalls <- StallEvent{Last: last, Detected: now, Window: l.stallWindow}:
+			default: // Throw it away if no one is listening.
+			}
+			if l.stallStop {
+				l.Stop(wrapLoopError(nil, TokenLoop, "No frame started for %s", now.Sub(last)))
+			}
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package gloop_test
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/erinpentecost/gloop"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStallDetector(t *testing.T) {
+	const window = 50 * time.Millisecond
+	var simulations int32
+	simulate := func(step time.Duration) error {
+		if atomic.AddInt32(&simulations, 1) == 5 {
+			// Hog the loop goroutine, as a starved scheduler would.
+			time.Sleep(4 * window)
+		}
+		return nil
+	}
+	loop, err := gloop.NewLoop(nil, simulate, gloop.Hz60Delay, 5*time.Millisecond, gloop.WithStallDetector(window, false))
+	assert.Nil(t, err)
+	assert.Nil(t, loop.Start())
+
+	select {
+	case stall := <-loop.Stalls():
+		assert.Equal(t, window, stall.Window)
+		assert.True(t, stall.Detected.Sub(stall.Last) >= window)
+	case <-time.After(time.Second):
+		assert.Fail(t, "no stall reported")
+	}
+
+	// Frames start again, and the loop keeps running.
+	time.Sleep(4 * window)
+	assert.True(t, atomic.LoadInt32(&simulations) > 5)
+	select {
+	case <-loop.Stalls():
+		assert.Fail(t, "one stall was reported twice")
+	default:
+	}
+	loop.Stop(nil)
+	<-loop.Done()
+	assert.Nil(t, loop.Err())
+	_, open := <-loop.Stalls()
+	assert.False(t, open)
+}
+
+func TestStallDetectorStops(t *testing.T) {
+	release := make(chan struct{})
+	simulate := func(step time.Duration) error {
+		<-release
+		return nil
+	}
+	loop, err := gloop.NewLoop(nil, simulate, gloop.Hz60Delay, gloop.Hz60Delay, gloop.WithStallDetector(20*time.Millisecond, true))
+	assert.Nil(t, err)
+	assert.Nil(t, loop.Start())
+
+	<-loop.Stalls()
+	close(release)
+	<-loop.Done()
+	loopErr, ok := loop.Err().(gloop.LoopError)
+	assert.True(t, ok)
+	assert.Equal(t, gloop.TokenLoop, loopErr.ErrorSource)
+}
+
+func TestStallDetectorValidation(t *testing.T) {
+	_, err := gloop.NewLoop(nil, nil, gloop.Hz60Delay, gloop.Hz60Delay, gloop.WithStallDetector(0, false))
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,83 @@
+package gloop
+
+import (
+	"time"
+)
+
+// RunStateful creates and starts a loop whose callbacks are handed state,
+// so world state doesn't have to be captured in closures.
+// render is also handed the interpolation alpha.
+// Either callback may be nil, for example to run headless.
+// Only one callback runs at a time, so neither needs to lock state.
+// For that reason, WithConcurrentRender is an error here.
+func RunStateful[S any](
+	state *S,
+	sim func(state *S, step time.Duration) error,
+	render func(state *S, step time.Duration, alpha float64) error,
+	RenderLatency, SimulationLatency time.Duration,
+	opts ...Option) (*Loop, error) {
+
+	loop, err := NewLoop(nil, nil, RenderLatency, SimulationLatency, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if loop.concurrentRender {
+		return nil, wrapLoopError(nil, TokenLoop, "RunStateful can't use WithConcurrentRender")
+	}
+	if sim != nil {
+		loop.Simulate = func(step time.Duration) error {
+			return sim(state, step)
+		}
+	}
+	if render != nil {
+		loop.Render = func(step time.Duration) error {
+			return render(state, step, loop.Alpha())
+		}
+	}
+	if err := loop.Start(); err != nil {
+		return nil, err
+	}
+	return loop, nil
+}
+
+// NewInterpolatedLoop creates a loop that keeps the previous and
+// current simulation state for you, and hands render a blend of the
+// two, so it can draw smoothly between simulation steps.
+// Before each step, the current state is copied to the previous one,
+// and sim moves cur forward, reading prev if it likes. Each render,
+// blend is called with both states and Alpha(), and render is passed
+// what it returns. Copies are plain assignments, so if S holds
+// pointers or slices, sim must replace them rather than change what
+// they point to. Both states start out as initial.
+// render may be nil. The loop is not started.
+// As with RunStateful, WithConcurrentRender is an error here.
+func NewInterpolatedLoop[S any](
+	initial S,
+	sim func(prev, cur *S, step time.Duration) error,
+	render func(blended S) error,
+	blend func(prev, cur S, alpha float64) S,
+	RenderLatency, SimulationLatency time.Duration,
+	opts ...Option) (*Loop, error) {
+
+	if sim == nil || blend == nil {
+		return nil, wrapLoopError(nil, TokenLoop, "NewInterpolatedLoop needs sim and blend")
+	}
+	loop, err := NewLoop(nil, nil, RenderLatency, SimulationLatency, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if loop.concurrentRender {
+		return nil, wrapLoopError(nil, TokenLoop, "NewInterpolatedLoop can't use WithConcurrentRender")
+	}
+	prev, cur := initial, initial
+	loop.Simulate = func(step time.Duration) error {
+		prev = cur
+		return sim(&prev, &cur, step)
+	}
+	if render != nil {
+		loop.Render = func(step time.Duration) error {
+			return render(blend(prev, cur, loop.Alpha()))
+		}
+	}
+	return loop, nil
+}
@@ -0,0 +1,110 @@
+package gloop_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/erinpentecost/gloop"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunStateful(t *testing.T) {
+	counter := 0
+	stop := make(chan interface{})
+	sim := func(state *int, step time.Duration) error {
+		*state++
+		if *state == 10 {
+			close(stop)
+		}
+		return nil
+	}
+	renders := 0
+	render := func(state *int, step time.Duration, alpha float64) error {
+		renders++
+		assert.True(t, alpha >= 0 && alpha <= 1)
+		return nil
+	}
+	loop, err := gloop.RunStateful(&counter, sim, render, gloop.Hz60Delay, gloop.Hz60Delay)
+	assert.Nil(t, err)
+	assert.NotNil(t, loop)
+
+	<-stop
+	loop.Stop(nil)
+	<-loop.Done()
+	assert.Nil(t, loop.Err())
+
+	// Simulate may run a few more times before Stop lands.
+	assert.True(t, counter >= 10)
+	assert.Equal(t, uint64(counter), loop.Stats().SimTicks)
+	assert.True(t, renders > 0)
+}
+
+func TestRunStatefulHeadless(t *testing.T) {
+	counter := 0
+	stop := make(chan interface{})
+	sim := func(state *int, step time.Duration) error {
+		*state++
+		if *state == 5 {
+			close(stop)
+		}
+		return nil
+	}
+	loop, err := gloop.RunStateful[int](&counter, sim, nil, gloop.Hz60Delay, gloop.Hz60Delay)
+	assert.Nil(t, err)
+
+	<-stop
+	loop.Stop(nil)
+	<-loop.Done()
+	assert.Nil(t, loop.Err())
+	assert.True(t, loop.Stats().RenderFrames > 0)
+}
+
+func TestRunStatefulConcurrentRender(t *testing.T) {
+	counter := 0
+	loop, err := gloop.RunStateful[int](&counter, nil, nil, gloop.Hz60Delay, gloop.Hz60Delay, gloop.WithConcurrentRender())
+	assert.NotNil(t, err)
+	assert.Nil(t, loop)
+}
+
+func TestNewInterpolatedLoop(t *testing.T) {
+	type world struct{ x float64 }
+	var latest float64
+	sim := func(prev, cur *world, step time.Duration) error {
+		assert.Equal(t, prev.x, cur.x)
+		cur.x++
+		latest = cur.x
+		return nil
+	}
+	lerp := func(prev, cur world, alpha float64) world {
+		return world{x: prev.x + (cur.x-prev.x)*alpha}
+	}
+	var blended []float64
+	var expected []float64
+	var loop *gloop.Loop
+	render := func(w world) error {
+		blended = append(blended, w.x)
+		if latest == 0 {
+			expected = append(expected, 0)
+		} else {
+			expected = append(expected, latest-0.75)
+		}
+		if len(blended) == 10 {
+			loop.Stop(nil)
+		}
+		return nil
+	}
+	// Pin alpha at a quarter of the way from prev to cur.
+	quarter := func(float64) float64 { return 0.25 }
+	loop, err := gloop.NewInterpolatedLoop(world{}, sim, render, lerp,
+		gloop.Hz60Delay, gloop.Hz60Delay, gloop.WithRenderEasing(quarter))
+	assert.Nil(t, err)
+
+	assert.Nil(t, loop.Start())
+	<-loop.Done()
+	assert.Nil(t, loop.Err())
+	assert.Equal(t, expected, blended)
+	assert.True(t, latest > 0)
+
+	_, err = gloop.NewInterpolatedLoop(world{}, sim, render, nil, gloop.Hz60Delay, gloop.Hz60Delay)
+	assert.NotNil(t, err)
+}
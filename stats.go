@@ -0,0 +1,112 @@
+package gloop
+
+import (
+	"math"
+	"reflect"
+)
+
+// LoopStats are running totals over the life of a loop.
+type LoopStats struct {
+	// SimTicks is how many times Simulate has been called.
+	SimTicks uint64
+	// RenderFrames is how many render frames have run.
+	RenderFrames uint64
+	// TotalDroppedSimTicks counts whole simulation steps that
+	// were thrown away instead of simulated.
+	TotalDroppedSimTicks uint64
+	// TotalDroppedRenderFrames counts render ticks skipped
+	// because the loop fell behind.
+	TotalDroppedRenderFrames uint64
+	// TotalOverruns counts callbacks that took longer than their latency.
+	TotalOverruns uint64
+	// TotalSpikes counts frames reported on Spikes().
+	TotalSpikes uint64
+
+	// With WithAllocSampling, these are how many allocations, and how
+	// many bytes, the most recently sampled call of each callback made.
+	RenderAllocs       uint64
+	RenderAllocBytes   uint64
+	SimulateAllocs     uint64
+	SimulateAllocBytes uint64
+}
+
+// Stats returns the loop's lifetime totals.
+func (l *Loop) Stats() LoopStats {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.stats
+}
+
+func (l *Loop) updateStats(fn func(stats *LoopStats)) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	fn(&l.stats)
+}
+
+// FieldDiff compares one LoopStats field between two runs.
+type FieldDiff struct {
+	// Field is the LoopStats field name, like "TotalOverruns".
+	Field    string
+	Baseline uint64
+	Current  uint64
+	// Delta is the change as a fraction of Baseline, so 0.1 is 10% more.
+	// It is +Inf if Baseline is 0 and Current isn't.
+	Delta float64
+	// Within is true if Delta is within the tolerance either way.
+	Within bool
+}
+
+// StatsDiff compares every LoopStats field between two runs.
+type StatsDiff struct {
+	Fields []FieldDiff
+}
+
+// Failed returns the fields that weren't within tolerance.
+func (d StatsDiff) Failed() []FieldDiff {
+	var failed []FieldDiff
+	for _, field := range d.Fields {
+		if !field.Within {
+			failed = append(failed, field)
+		}
+	}
+	return failed
+}
+
+// CompareStats compares current against baseline field by field,
+// for performance checks in CI. tol is the fraction each field may
+// change by in either direction, so 0.05 allows 5%.
+// Only unsigned integer fields are compared.
+// It returns true if every field is within tol.
+func CompareStats(baseline, current LoopStats, tol float64) (StatsDiff, bool) {
+	return compareFields(reflect.ValueOf(baseline), reflect.ValueOf(current), tol)
+}
+
+// compareFields is CompareStats for any two structs of the same type.
+// Fields that aren't unsigned integers are skipped.
+func compareFields(base, cur reflect.Value, tol float64) (StatsDiff, bool) {
+	var diff StatsDiff
+	ok := true
+	for i := 0; i < base.NumField(); i++ {
+		if !base.Field(i).CanUint() {
+			continue
+		}
+		b, c := base.Field(i).Uint(), cur.Field(i).Uint()
+		field := FieldDiff{
+			Field:    base.Type().Field(i).Name,
+			Baseline: b,
+			Current:  c,
+		}
+		switch {
+		case b == c:
+			field.Delta = 0
+		case b == 0:
+			field.Delta = math.Inf(1)
+		default:
+			field.Delta = (float64(c) - float64(b)) / float64(b)
+		}
+		field.Within = math.Abs(field.Delta) <= tol
+		ok = ok && field.Within
+		diff.Fields = append(diff.Fields, field)
+	}
+	return diff, ok
+}
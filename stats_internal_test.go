@@ -0,0 +1,25 @@
+package gloop
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompareFieldsSkipsOtherKinds(t *testing.T) {
+	type mixed struct {
+		Ticks uint64
+		Name  string
+		Rate  float64
+		Count uint32
+	}
+	baseline := mixed{Ticks: 100, Name: "baseline", Rate: 1, Count: 10}
+	current := mixed{Ticks: 102, Name: "current", Rate: 2, Count: 10}
+
+	diff, ok := compareFields(reflect.ValueOf(baseline), reflect.ValueOf(current), 0.05)
+	assert.True(t, ok)
+	assert.Len(t, diff.Fields, 2)
+	assert.Equal(t, "Ticks", diff.Fields[0].Field)
+	assert.Equal(t, "Count", diff.Fields[1].Field)
+}
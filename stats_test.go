@@ -0,0 +1,71 @@
+package gloop_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/erinpentecost/gloop"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatsTotals(t *testing.T) {
+	simulations := 0
+	simulate := func(step time.Duration) error {
+		simulations++
+		return nil
+	}
+	loop, err := gloop.NewLoop(nil, simulate, gloop.Hz60Delay, gloop.Hz60Delay, gloop.WithMaxSimStepsPerFrame(1))
+	assert.Nil(t, err)
+	assert.NotNil(t, loop)
+	renders := 0
+	loop.Render = func(step time.Duration) error {
+		renders++
+		if renders == 3 || renders == 6 {
+			time.Sleep(4 * gloop.Hz60Delay)
+		}
+		if renders == 9 {
+			loop.Stop(nil)
+		}
+		return nil
+	}
+	err = loop.Start()
+	assert.Nil(t, err)
+	<-loop.Done()
+	assert.Nil(t, loop.Err())
+
+	stats := loop.Stats()
+	assert.Equal(t, uint64(simulations), stats.SimTicks)
+	assert.Equal(t, uint64(renders), stats.RenderFrames)
+	assert.Equal(t, uint64(2), stats.TotalOverruns)
+	assert.Equal(t, uint64(2), stats.TotalSpikes)
+	assert.True(t, stats.TotalDroppedSimTicks >= 2)
+	assert.True(t, stats.TotalDroppedRenderFrames >= 2)
+}
+
+func TestCompareStats(t *testing.T) {
+	baseline := gloop.LoopStats{SimTicks: 1000, RenderFrames: 500, TotalOverruns: 10}
+
+	current := baseline
+	current.SimTicks = 1020
+	diff, ok := gloop.CompareStats(baseline, current, 0.05)
+	assert.True(t, ok)
+	assert.Empty(t, diff.Failed())
+	assert.Equal(t, "SimTicks", diff.Fields[0].Field)
+	assert.InDelta(t, 0.02, diff.Fields[0].Delta, 1e-9)
+
+	// Overruns went up by half.
+	current.TotalOverruns = 15
+	current.TotalSpikes = 1
+	diff, ok = gloop.CompareStats(baseline, current, 0.05)
+	assert.False(t, ok)
+	failed := diff.Failed()
+	assert.Len(t, failed, 2)
+	assert.Equal(t, "TotalOverruns", failed[0].Field)
+	assert.Equal(t, uint64(10), failed[0].Baseline)
+	assert.Equal(t, uint64(15), failed[0].Current)
+	assert.InDelta(t, 0.5, failed[0].Delta, 1e-9)
+	// Anything from nothing is an infinite change.
+	assert.Equal(t, "TotalSpikes", failed[1].Field)
+	assert.True(t, math.IsInf(failed[1].Delta, 1))
+}
@@ -0,0 +1,120 @@
+package gloop
+
+import (
+	"time"
+)
+
+// A loop that has not been started can be driven by hand instead.
+// This is step mode: each call runs callbacks on the calling goroutine
+// and returns once they finish, with no timers involved.
+// Step mode is meant for headless simulation and tests.
+// Once a loop is started or stopped, it can't be stepped.
+
+// WithStepRender renders once at the end of each StepN batch.
+// Render is passed the total simulated time of the batch.
+func WithStepRender() Option {
+	return func(l *Loop) error {
+		l.stepRender = true
+		return nil
+	}
+}
+
+// stepRunner returns the runner used for step mode.
+func (l *Loop) stepRunner() (*runner, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.curState != stateInit {
+		return nil, wrapLoopError(nil, TokenLoop, "Loop can only be stepped before it is started")
+	}
+	if l.stepper == nil {
+		l.stepper = newRunner(l)
+	}
+	return l.stepper, nil
+}
+
+// StepN runs n fixed Simulate steps in step mode.
+// It returns the first error from Simulate, with the index of the
+// failing step recorded under "tick" in the LoopError's Misc.
+// Stepping ends early, without an error, if Stop is called.
+func (l *Loop) StepN(n int) error {
+	r, err := l.stepRunner()
+	if err != nil {
+		return err
+	}
+
+	for i := 0; i < n; i++ {
+		if l.stopping() {
+			return nil
+		}
+		if err := r.stepSimulate(i); err != nil {
+			return err
+		}
+	}
+
+	r.publishInterpolation()
+	l.runCommits()
+
+	if l.stepRender && n > 0 && !l.stopping() {
+		step := time.Duration(n) * l.SimulationLatency
+		return r.renderStep(step, time.Now().Add(l.RenderLatency))
+	}
+	return nil
+}
+
+// Warmup runs n iterations in step mode, each one Simulate step
+// followed by one Render, so stats like RecentFrameTimes hold real
+// service times by the time the loop is started.
+// Errors are returned as they are by StepN.
+func (l *Loop) Warmup(n int) error {
+	r, err := l.stepRunner()
+	if err != nil {
+		return err
+	}
+
+	for i := 0; i < n; i++ {
+		if l.stopping() {
+			return nil
+		}
+		if _, _, err := r.stepFrame(i); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// stepFrame runs one Simulate step and one Render in step mode,
+// and returns how long each took.
+func (r *runner) stepFrame(tick int) (simulate, render time.Duration, err error) {
+	l := r.l
+	start := time.Now()
+	if err := r.stepSimulate(tick); err != nil {
+		return 0, 0, err
+	}
+	simulate = time.Since(start)
+	r.publishInterpolation()
+	l.runCommits()
+
+	start = time.Now()
+	if err := r.renderStep(l.RenderLatency, start.Add(l.RenderLatency)); err != nil {
+		return simulate, 0, err
+	}
+	return simulate, time.Since(start), nil
+}
+
+// stepSimulate runs Simulate once in step mode.
+// tick is recorded in the LoopError if it fails.
+func (r *runner) stepSimulate(tick int) error {
+	l := r.l
+	start := time.Now()
+	step := r.simStep()
+	if er := r.simulate(step); er != nil {
+		wrapped := wrapLoopError(er, TokenSimulate, "Error returned by Simulate(%s)", step.String())
+		wrapped.Misc["curTime"] = start
+		wrapped.Misc["tick"] = tick
+		l.reportError(wrapped)
+		return wrapped
+	}
+	r.serviced(TokenSimulate, start, step, step)
+	r.advanceSimStep()
+	return nil
+}
@@ -0,0 +1,104 @@
+package gloop_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/erinpentecost/gloop"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStepN(t *testing.T) {
+	renders := 0
+	render := func(step time.Duration) error {
+		renders++
+		assert.Equal(t, 100*gloop.Hz60Delay, step)
+		return nil
+	}
+	simulations := 0
+	simulate := func(step time.Duration) error {
+		simulations++
+		assert.Equal(t, gloop.Hz60Delay, step)
+		return nil
+	}
+	loop, err := gloop.NewLoop(render, simulate, gloop.Hz60Delay, gloop.Hz60Delay, gloop.WithStepRender())
+	assert.Nil(t, err)
+	assert.NotNil(t, loop)
+
+	assert.Nil(t, loop.StepN(100))
+	assert.Equal(t, 100, simulations)
+	assert.Equal(t, 1, renders)
+}
+
+func TestStepNError(t *testing.T) {
+	render := func(step time.Duration) error {
+		return nil
+	}
+	simulations := 0
+	simulate := func(step time.Duration) error {
+		simulations++
+		if simulations == 10 {
+			return fmt.Errorf("Intentional error")
+		}
+		return nil
+	}
+	loop, err := gloop.NewLoop(render, simulate, gloop.Hz60Delay, gloop.Hz60Delay)
+	assert.Nil(t, err)
+	assert.NotNil(t, loop)
+
+	err = loop.StepN(100)
+	assert.NotNil(t, err)
+	assert.Equal(t, 10, simulations)
+	loopErr, ok := err.(gloop.LoopError)
+	assert.True(t, ok)
+	assert.Equal(t, 9, loopErr.Misc["tick"])
+}
+
+func TestStepNAfterStart(t *testing.T) {
+	loop, err := gloop.NewLoop(nil, nil, gloop.Hz60Delay, gloop.Hz60Delay)
+	assert.Nil(t, err)
+	assert.NotNil(t, loop)
+	err = loop.Start()
+	assert.Nil(t, err)
+	assert.NotNil(t, loop.StepN(1))
+	loop.Stop(nil)
+	<-loop.Done()
+}
+
+func TestWarmup(t *testing.T) {
+	var order []string
+	render := func(step time.Duration) error {
+		order = append(order, "render")
+		time.Sleep(time.Millisecond)
+		return nil
+	}
+	simulate := func(step time.Duration) error {
+		order = append(order, "simulate")
+		return nil
+	}
+	loop, err := gloop.NewLoop(render, simulate, gloop.Hz60Delay, gloop.Hz60Delay)
+	assert.Nil(t, err)
+	assert.NotNil(t, loop)
+
+	assert.Nil(t, loop.Warmup(10))
+	assert.Len(t, order, 20)
+	for i := 0; i < len(order); i += 2 {
+		assert.Equal(t, "simulate", order[i])
+		assert.Equal(t, "render", order[i+1])
+	}
+
+	stats := loop.Stats()
+	assert.Equal(t, uint64(10), stats.SimTicks)
+	assert.Equal(t, uint64(10), stats.RenderFrames)
+	times := loop.RecentFrameTimes()
+	assert.Len(t, times, 10)
+	for _, d := range times {
+		assert.True(t, d >= time.Millisecond)
+	}
+
+	// Going live afterwards is fine.
+	assert.Nil(t, loop.Start())
+	loop.Stop(nil)
+	<-loop.Done()
+}
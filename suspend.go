@@ -0,0 +1,30 @@
+package gloop
+
+// Suspend stops both Simulate and Render from being called, for when
+// the app is in the background. Time doesn't build up while suspended,
+// however long that is, so on Resume the simulation carries on from
+// where it was instead of catching up, and the first frames after
+// have their usual deltas. Heartbeats keep coming.
+// Unlike PauseSimulation, Render stops too.
+func (l *Loop) Suspend() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.suspended = true
+}
+
+// Resume undoes Suspend.
+func (l *Loop) Resume() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.suspended {
+		l.suspended = false
+		l.resumed()
+	}
+}
+
+// Suspended is true between Suspend and Resume.
+func (l *Loop) Suspended() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.suspended
+}
@@ -0,0 +1,68 @@
+package gloop_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/erinpentecost/gloop"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSuspend(t *testing.T) {
+	const simLatency = 5 * time.Millisecond
+	var mu sync.Mutex
+	simulations := 0
+	var renderSteps []time.Duration
+	simulate := func(step time.Duration) error {
+		mu.Lock()
+		defer mu.Unlock()
+		simulations++
+		return nil
+	}
+	render := func(step time.Duration) error {
+		mu.Lock()
+		defer mu.Unlock()
+		renderSteps = append(renderSteps, step)
+		return nil
+	}
+	counts := func() (int, int) {
+		mu.Lock()
+		defer mu.Unlock()
+		return simulations, len(renderSteps)
+	}
+	loop, err := gloop.NewLoop(render, simulate, gloop.Hz60Delay, simLatency,
+		gloop.WithRenderSkipPolicy(gloop.RenderDropToLatest))
+	assert.Nil(t, err)
+	assert.Nil(t, loop.Start())
+	defer func() {
+		loop.Stop(nil)
+		<-loop.Done()
+	}()
+
+	time.Sleep(100 * time.Millisecond)
+	loop.Suspend()
+	assert.True(t, loop.Suspended())
+	// Let a frame already in progress finish.
+	time.Sleep(2 * gloop.Hz60Delay)
+	sims, renders := counts()
+
+	// Stand-in for the app sitting in the background for minutes.
+	time.Sleep(500 * time.Millisecond)
+	afterSims, afterRenders := counts()
+	assert.Equal(t, sims, afterSims)
+	assert.Equal(t, renders, afterRenders)
+
+	loop.Resume()
+	assert.False(t, loop.Suspended())
+	time.Sleep(50 * time.Millisecond)
+	afterSims, afterRenders = counts()
+
+	// About 10 steps, not the hundred that were missed.
+	assert.True(t, afterSims-sims <= 20, "steps after resume: %d", afterSims-sims)
+	assert.True(t, afterRenders > renders)
+	mu.Lock()
+	first := renderSteps[renders]
+	mu.Unlock()
+	assert.True(t, first < 2*gloop.Hz60Delay, "first step after resume: %s", first)
+}
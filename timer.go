@@ -0,0 +1,19 @@
+package gloop
+
+// The OS timer resolution is raised while a loop runs, so timers
+// actually fire at the configured rates. These are swapped out in tests.
+var (
+	beginTimerPeriod = platformBeginTimerPeriod
+	endTimerPeriod   = platformEndTimerPeriod
+)
+
+// WithoutTimerResolution leaves the OS timer resolution alone.
+// By default, on Windows, the loop requests 1ms timer resolution
+// while it runs, since the default of ~15.6ms can't hold 60 Hz.
+// Other platforms are unaffected either way.
+func WithoutTimerResolution() Option {
+	return func(l *Loop) error {
+		l.keepTimerResolution = true
+		return nil
+	}
+}
@@ -0,0 +1,46 @@
+package gloop
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// swapTimerPeriod counts calls to the timer resolution hooks
+// until the returned func is called.
+func swapTimerPeriod(begins, ends *int) func() {
+	oldBegin, oldEnd := beginTimerPeriod, endTimerPeriod
+	beginTimerPeriod = func() { *begins++ }
+	endTimerPeriod = func() { *ends++ }
+	return func() {
+		beginTimerPeriod, endTimerPeriod = oldBegin, oldEnd
+	}
+}
+
+func TestTimerResolution(t *testing.T) {
+	begins, ends := 0, 0
+	defer swapTimerPeriod(&begins, &ends)()
+
+	loop, err := NewLoop(nil, nil, Hz60Delay, Hz60Delay)
+	assert.Nil(t, err)
+	assert.Nil(t, loop.Start())
+	loop.Stop(nil)
+	assert.Nil(t, loop.Close())
+
+	assert.Equal(t, 1, begins)
+	assert.Equal(t, 1, ends)
+}
+
+func TestWithoutTimerResolution(t *testing.T) {
+	begins, ends := 0, 0
+	defer swapTimerPeriod(&begins, &ends)()
+
+	loop, err := NewLoop(nil, nil, Hz60Delay, Hz60Delay, WithoutTimerResolution())
+	assert.Nil(t, err)
+	assert.Nil(t, loop.Start())
+	loop.Stop(nil)
+	assert.Nil(t, loop.Close())
+
+	assert.Equal(t, 0, begins)
+	assert.Equal(t, 0, ends)
+}
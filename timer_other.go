@@ -0,0 +1,10 @@
+//go:build !windows
+// +build !windows
+
+package gloop
+
+// Timer resolution is already fine everywhere else.
+
+func platformBeginTimerPeriod() {}
+
+func platformEndTimerPeriod() {}
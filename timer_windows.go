@@ -0,0 +1,29 @@
+//go:build windows
+// +build windows
+
+package gloop
+
+import (
+	"syscall"
+)
+
+var (
+	winmm           = syscall.NewLazyDLL("winmm.dll")
+	timeBeginPeriod = winmm.NewProc("timeBeginPeriod")
+	timeEndPeriod   = winmm.NewProc("timeEndPeriod")
+)
+
+// timerPeriodMs is the timer resolution requested from Windows.
+const timerPeriodMs = 1
+
+func platformBeginTimerPeriod() {
+	if timeBeginPeriod.Find() == nil {
+		timeBeginPeriod.Call(uintptr(timerPeriodMs))
+	}
+}
+
+func platformEndTimerPeriod() {
+	if timeEndPeriod.Find() == nil {
+		timeEndPeriod.Call(uintptr(timerPeriodMs))
+	}
+}
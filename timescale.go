@@ -0,0 +1,93 @@
+package gloop
+
+import (
+	"math"
+	"time"
+)
+
+// timeScaleRamp moves the time scale from one value to another.
+type timeScaleRamp struct {
+	from, to float64
+	start    time.Time
+	over     time.Duration
+}
+
+// SetTimeScale sets how fast simulation time passes compared to real time.
+// At 0.25, Simulate is called a quarter as often, still with a step of
+// SimulationLatency. At 0 the simulation is frozen.
+// It cancels any ramp in progress. The default is 1.
+// Scales less than 0, NaN, or infinite are an error.
+func (l *Loop) SetTimeScale(scale float64) error {
+	if err := checkTimeScale(scale); err != nil {
+		return err
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.timeScale = scale
+	l.scaleRamp = nil
+	return nil
+}
+
+// TimeScaleRamp moves the time scale linearly from where it is now
+// to target, over the given amount of real time.
+// Use it for slow motion that eases in instead of snapping.
+// A ramp over 0 or less sets target right away.
+// Targets less than 0, NaN, or infinite are an error.
+func (l *Loop) TimeScaleRamp(target float64, over time.Duration) error {
+	if over <= 0 {
+		return l.SetTimeScale(target)
+	}
+	if err := checkTimeScale(target); err != nil {
+		return err
+	}
+	now := time.Now()
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.scaleRamp = &timeScaleRamp{
+		from:  l.timeScaleLocked(now),
+		to:    target,
+		start: now,
+		over:  over,
+	}
+	return nil
+}
+
+func checkTimeScale(scale float64) error {
+	if math.IsNaN(scale) || math.IsInf(scale, 0) {
+		return wrapLoopError(nil, TokenLoop, "TimeScale must be a finite number")
+	}
+	if scale < 0 {
+		return wrapLoopError(nil, TokenLoop, "TimeScale can't be lt 0")
+	}
+	return nil
+}
+
+// TimeScale is the time scale in effect right now.
+func (l *Loop) TimeScale() float64 {
+	return l.timeScaleAt(time.Now())
+}
+
+func (l *Loop) timeScaleAt(now time.Time) float64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.timeScaleLocked(now)
+}
+
+// timeScaleLocked advances any ramp to now. Hold l.mu to call it.
+func (l *Loop) timeScaleLocked(now time.Time) float64 {
+	ramp := l.scaleRamp
+	if ramp == nil {
+		return l.timeScale
+	}
+	progress := float64(now.Sub(ramp.start)) / float64(ramp.over)
+	if progress >= 1 {
+		l.timeScale = ramp.to
+		l.scaleRamp = nil
+		return l.timeScale
+	}
+	if progress < 0 {
+		progress = 0
+	}
+	l.timeScale = ramp.from + (ramp.to-ramp.from)*progress
+	return l.timeScale
+}
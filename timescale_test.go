@@ -0,0 +1,125 @@
+package gloop_test
+
+import (
+	"math"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/erinpentecost/gloop"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimeScaleRamp(t *testing.T) {
+	loop, err := gloop.NewLoop(nil, nil, gloop.Hz60Delay, gloop.Hz60Delay)
+	assert.Nil(t, err)
+	assert.Equal(t, 1.0, loop.TimeScale())
+
+	const over = 200 * time.Millisecond
+	assert.Nil(t, loop.TimeScaleRamp(0.25, over))
+	end := time.Now().Add(over + 50*time.Millisecond)
+
+	previous := loop.TimeScale()
+	assert.True(t, previous <= 1)
+	assert.True(t, previous > 0.9)
+	sawMiddle := false
+	for time.Now().Before(end) {
+		time.Sleep(10 * time.Millisecond)
+		scale := loop.TimeScale()
+		assert.True(t, scale <= previous)
+		if scale > 0.3 && scale < 0.95 {
+			sawMiddle = true
+		}
+		previous = scale
+	}
+	assert.True(t, sawMiddle)
+	assert.Equal(t, 0.25, loop.TimeScale())
+
+	assert.NotNil(t, loop.TimeScaleRamp(-1, over))
+	assert.NotNil(t, loop.SetTimeScale(-1))
+	assert.NotNil(t, loop.SetTimeScale(math.NaN()))
+	assert.NotNil(t, loop.SetTimeScale(math.Inf(1)))
+	assert.NotNil(t, loop.TimeScaleRamp(math.NaN(), over))
+	assert.NotNil(t, loop.TimeScaleRamp(math.Inf(1), over))
+	assert.Nil(t, loop.SetTimeScale(2))
+	assert.Equal(t, 2.0, loop.TimeScale())
+}
+
+func TestTimeScaleSlowsSimulation(t *testing.T) {
+	const simLatency = gloop.Hz60Delay / 2
+	calls := 0
+	simulate := func(step time.Duration) error {
+		assert.Equal(t, simLatency, step)
+		calls++
+		return nil
+	}
+	loop, err := gloop.NewLoop(nil, simulate, gloop.Hz60Delay, simLatency)
+	assert.Nil(t, err)
+	assert.Nil(t, loop.SetTimeScale(0.25))
+
+	assert.Nil(t, loop.Start())
+	<-time.After(500 * time.Millisecond)
+	loop.Stop(nil)
+	<-loop.Done()
+
+	// 60 steps in real time, so 15 at a quarter speed.
+	assert.True(t, calls >= 10, "calls: %d", calls)
+	assert.True(t, calls <= 20, "calls: %d", calls)
+}
+
+// countTicks counts Simulate calls on a started loop.
+func countTicks(t *testing.T) (*gloop.Loop, *int32) {
+	var calls int32
+	simulate := func(step time.Duration) error {
+		atomic.AddInt32(&calls, 1)
+		return nil
+	}
+	loop, err := gloop.NewLoop(nil, simulate, gloop.Hz60Delay, gloop.Hz60Delay)
+	assert.Nil(t, err)
+	return loop, &calls
+}
+
+func TestTimeScaleSpeedsUpPromptly(t *testing.T) {
+	loop, calls := countTicks(t)
+	assert.Nil(t, loop.SetTimeScale(0.01))
+	assert.Nil(t, loop.Start())
+	time.Sleep(100 * time.Millisecond)
+
+	assert.Nil(t, loop.SetTimeScale(1))
+	time.Sleep(50 * time.Millisecond)
+	before := atomic.LoadInt32(calls)
+	time.Sleep(250 * time.Millisecond)
+	after := atomic.LoadInt32(calls)
+	loop.Stop(nil)
+	<-loop.Done()
+
+	// 15 ticks in real time.
+	assert.True(t, after-before >= 8, "ticks: %d", after-before)
+	assert.True(t, after-before <= 22, "ticks: %d", after-before)
+}
+
+func TestTimeScaleRampWhileRunning(t *testing.T) {
+	loop, calls := countTicks(t)
+	assert.Nil(t, loop.SetTimeScale(0))
+	assert.Nil(t, loop.Start())
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, int32(0), atomic.LoadInt32(calls))
+
+	const over = 400 * time.Millisecond
+	assert.Nil(t, loop.TimeScaleRamp(1, over))
+	time.Sleep(over / 2)
+	during := atomic.LoadInt32(calls)
+	time.Sleep(over / 2)
+	ramped := atomic.LoadInt32(calls)
+	time.Sleep(250 * time.Millisecond)
+	after := atomic.LoadInt32(calls)
+	loop.Stop(nil)
+	<-loop.Done()
+
+	// About a quarter of the 24 real-time ticks happen in the
+	// first half of the ramp, and three quarters in the second.
+	assert.True(t, during >= 2, "ticks during: %d", during)
+	assert.True(t, ramped-during >= 8, "ticks late in ramp: %d", ramped-during)
+	assert.True(t, after-ramped >= 8, "ticks after: %d", after-ramped)
+	assert.True(t, after-ramped <= 22, "ticks after: %d", after-ramped)
+}
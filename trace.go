@@ -0,0 +1,28 @@
+package gloop
+
+import (
+	"context"
+	"runtime/trace"
+	"time"
+)
+
+// Execution trace regions wrapped around Render and Simulate,
+// so they are easy to find in go tool trace.
+const (
+	renderRegion   = "gloop.render"
+	simulateRegion = "gloop.simulate"
+)
+
+// traced runs fn inside a trace region named region, and logs step.
+// When runtime/trace isn't recording, fn is called directly.
+func traced(ctx context.Context, region string, step time.Duration, fn func() error) error {
+	if !trace.IsEnabled() {
+		return fn()
+	}
+	var err error
+	trace.WithRegion(ctx, region, func() {
+		trace.Log(ctx, "step", step.String())
+		err = fn()
+	})
+	return err
+}
@@ -0,0 +1,29 @@
+package gloop_test
+
+import (
+	"bytes"
+	"runtime/trace"
+	"testing"
+	"time"
+
+	"github.com/erinpentecost/gloop"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTraceRegions(t *testing.T) {
+	nothing := func(step time.Duration) error { return nil }
+	loop, err := gloop.NewLoop(nothing, nothing, time.Millisecond, time.Millisecond)
+	assert.Nil(t, err)
+
+	var buf bytes.Buffer
+	assert.Nil(t, trace.Start(&buf))
+	assert.Nil(t, loop.Start())
+	<-time.After(50 * time.Millisecond)
+	loop.Stop(nil)
+	<-loop.Done()
+	trace.Stop()
+
+	// Region names end up in the trace's string table.
+	assert.True(t, bytes.Contains(buf.Bytes(), []byte("gloop.render")))
+	assert.True(t, bytes.Contains(buf.Bytes(), []byte("gloop.simulate")))
+}
@@ -0,0 +1,40 @@
+package gloop
+
+import (
+	"time"
+)
+
+// WithVariableSimulate calls Simulate once per simulate frame with the
+// time that really passed since the last one, like Render, instead of
+// in fixed steps. There is no accumulator and no catch-up: a slow frame
+// just means a longer step next time.
+// This is simpler for games that don't need it, but the results depend
+// on timing, so the simulation is not deterministic, and can become
+// unstable if a step gets long. WithMaxPhysicsStep can help with that.
+// The time scale still applies. Alpha() is always 0, and
+// SetAccumulator, WithBatchSimulate and the catch-up limits have no
+// effect.
+func WithVariableSimulate() Option {
+	return func(l *Loop) error {
+		l.variableSim = true
+		return nil
+	}
+}
+
+// simulateElapsed calls Simulate once with elapsed as the step.
+func (r *runner) simulateElapsed(curTime time.Time, elapsed time.Duration) {
+	l := r.l
+	if elapsed <= 0 || l.stopping() {
+		return
+	}
+	start := time.Now()
+	if er := r.simulate(elapsed); er != nil {
+		wrapped := wrapLoopError(er, TokenSimulate, "Error returned by Simulate(%s)", elapsed.String())
+		wrapped.Misc["curTime"] = curTime
+		l.reportError(wrapped)
+		l.Stop(wrapped)
+		return
+	}
+	r.serviced(TokenSimulate, start, elapsed, l.SimulationLatency)
+	r.simLatency.MarkDone(elapsed)
+}
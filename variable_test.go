@@ -0,0 +1,56 @@
+package gloop_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/erinpentecost/gloop"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVariableSimulate(t *testing.T) {
+	const latency = 10 * time.Millisecond
+	var steps []time.Duration
+	var calls []time.Time
+	loop, err := gloop.NewLoop(nil, nil, time.Second, latency, gloop.WithVariableSimulate())
+	assert.Nil(t, err)
+	loop.Simulate = func(step time.Duration) error {
+		steps = append(steps, step)
+		calls = append(calls, time.Now())
+		// Every third frame runs long, so the next step is longer.
+		if len(steps)%3 == 0 {
+			time.Sleep(3 * latency)
+		}
+		if len(steps) == 12 {
+			loop.Stop(nil)
+		}
+		return nil
+	}
+	start := time.Now()
+	assert.Nil(t, loop.Start())
+	<-loop.Done()
+	assert.Nil(t, loop.Err())
+
+	assert.Len(t, steps, 12)
+	// The steps add up to the time that passed before the last one.
+	elapsed := calls[len(calls)-1].Sub(start)
+	var total time.Duration
+	for _, step := range steps {
+		total += step
+	}
+	assert.True(t, total <= elapsed)
+	assert.True(t, total > elapsed-2*latency, "steps %s of %s", total, elapsed)
+	// After each long frame, the next step covered it all at once,
+	// instead of several fixed steps catching up.
+	for i := 3; i < len(steps); i += 3 {
+		assert.True(t, steps[i] >= 3*latency, "step %d was %s", i, steps[i])
+		assert.True(t, calls[i].Sub(calls[i-1]) >= 3*latency)
+	}
+	// The others were about one frame.
+	for i := 1; i < len(steps); i++ {
+		if i%3 != 0 {
+			assert.True(t, steps[i] < 3*latency, "step %d was %s", i, steps[i])
+		}
+	}
+	assert.Equal(t, 0.0, loop.Alpha())
+}